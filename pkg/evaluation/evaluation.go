@@ -0,0 +1,249 @@
+// Package evaluation evaluates measured service indicators against service objectives.
+//
+//	result, err := evaluation.Evaluate(indicators, objectives, map[string]float64{"request_latency_seconds": 0.4})
+//	if result.Status == evaluation.StatusFail {
+//		...
+//	}
+//
+// Objectives with relative criteria like "<=+10%" are compared against earlier evaluations and require an Evaluator with a HistoryStore:
+//
+//	evaluator := evaluation.NewEvaluator(evaluation.NewMemoryHistoryStore())
+//	result, err := evaluator.Evaluate(project, stage, service, indicators, objectives, values)
+//
+// The values of the service indicators are measured by the SLIProvider registered for their source in SLIProviders.
+package evaluation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+// Status of an evaluation
+const (
+	// StatusPass indicates that the total score reached the pass percentage
+	StatusPass = "pass"
+	// StatusWarning indicates that the total score reached the warning but not the pass percentage
+	StatusWarning = "warning"
+	// StatusFail indicates that the total score did not reach the warning percentage
+	StatusFail = "fail"
+)
+
+// Result is the result of evaluating the objectives of a service
+type Result struct {
+	// Status is pass, warning or fail
+	Status string `json:"status"`
+	// Score is the achieved score in percent of the maximum score, rounded to two decimal places
+	Score float64 `json:"score"`
+	// AchievedScore is the sum of the scores of the passed objectives
+	AchievedScore float64 `json:"achievedScore"`
+	// MaximumScore is the sum of the scores of all objectives
+	MaximumScore float64 `json:"maximumScore"`
+	// Pass is the percentage required for passing the evaluation
+	Pass int `json:"pass"`
+	// Warning is the percentage required for a warning
+	Warning    int                `json:"warning"`
+	Objectives []*ObjectiveResult `json:"objectives"`
+}
+
+// ObjectiveResult is the result of evaluating a single objective
+type ObjectiveResult struct {
+	Metric string `json:"metric"`
+	// Value is the measured value of the metric. It is nil if no value has been measured.
+	Value *float64 `json:"value"`
+	// Threshold is the threshold of an objective without criteria
+	Threshold float64 `json:"threshold,omitempty"`
+	// Criteria are the criteria of the objective
+	Criteria []string `json:"criteria,omitempty"`
+	// ComparisonValue is the average value of the metric in earlier evaluations relative criteria have been compared against
+	ComparisonValue *float64 `json:"comparisonValue,omitempty"`
+	Timeframe       string   `json:"timeframe,omitempty"`
+	Passed          bool     `json:"passed"`
+	// Score is the score achieved by the objective, i.e. its maximum score if it passed and 0 otherwise
+	Score float64 `json:"score"`
+	// MaximumScore is the score defined for the objective
+	MaximumScore float64 `json:"maximumScore"`
+	// Message explains why the objective did not pass
+	Message string `json:"message,omitempty"`
+}
+
+// Evaluator evaluates objectives and compares relative criteria against the results of earlier evaluations
+type Evaluator struct {
+	// History provides the results of earlier evaluations and stores the new results. If nil, objectives
+	// with relative criteria cannot be evaluated.
+	History HistoryStore
+}
+
+// NewEvaluator returns a new Evaluator using the provided history
+func NewEvaluator(history HistoryStore) *Evaluator {
+	return &Evaluator{
+		History: history,
+	}
+}
+
+// Evaluate compares the measured values of the service indicators against the objectives. An objective passes if
+// the value of its metric does not exceed the threshold. Objectives without a measured value fail.
+// If indicators is not nil, all objectives must refer to a defined indicator.
+func Evaluate(indicators *models.ServiceIndicators, objectives *models.ServiceObjectives, values map[string]float64) (*Result, error) {
+	return (&Evaluator{}).Evaluate("", "", "", indicators, objectives, values)
+}
+
+// Evaluate compares the measured values of the service indicators against the objectives. An objective passes if
+// its value meets all of its criteria, or does not exceed its threshold if it has no criteria. Relative criteria are
+// compared against the average value of the metric in the last passed evaluations of the service. If there are
+// no such evaluations, relative criteria are met, so the first evaluation establishes the baseline.
+// The result is stored in the history.
+func (e *Evaluator) Evaluate(project string, stage string, service string, indicators *models.ServiceIndicators,
+	objectives *models.ServiceObjectives, values map[string]float64) (*Result, error) {
+
+	if objectives == nil {
+		return nil, fmt.Errorf("Error when evaluating objectives: no objectives defined")
+	}
+	if objectives.Warning > objectives.Pass {
+		return nil, fmt.Errorf("Error when evaluating objectives: warning percentage %d exceeds pass percentage %d", objectives.Warning, objectives.Pass)
+	}
+	if indicators != nil {
+		if err := checkIndicators(indicators, objectives); err != nil {
+			return nil, err
+		}
+	}
+
+	criteria := map[*models.ServiceObjective][]*models.Criterion{}
+	relative := false
+	for _, objective := range objectives.Objectives {
+		objectiveCriteria, err := objective.GetCriteria()
+		if err != nil {
+			return nil, fmt.Errorf("Error when evaluating objective %s: %s", objective.Metric, err.Error())
+		}
+		criteria[objective] = objectiveCriteria
+		for _, criterion := range objectiveCriteria {
+			relative = relative || criterion.Relative
+		}
+	}
+
+	comparisonValues := map[string]float64{}
+	if relative {
+		if e.History == nil {
+			return nil, fmt.Errorf("Error when evaluating objectives: relative criteria require a history of earlier evaluations")
+		}
+		earlierResults, err := e.History.GetPassedResults(project, stage, service, objectives.GetNumberOfComparisonResults())
+		if err != nil {
+			return nil, fmt.Errorf("Error when reading earlier evaluations: %s", err.Error())
+		}
+		comparisonValues = averageValues(earlierResults)
+	}
+
+	result := &Result{
+		Pass:       objectives.Pass,
+		Warning:    objectives.Warning,
+		Objectives: []*ObjectiveResult{},
+	}
+	for _, objective := range objectives.Objectives {
+		objectiveResult := evaluateObjective(objective, criteria[objective], values, comparisonValues)
+		result.AchievedScore += objectiveResult.Score
+		result.MaximumScore += objectiveResult.MaximumScore
+		result.Objectives = append(result.Objectives, objectiveResult)
+	}
+
+	result.Score = 100
+	if result.MaximumScore > 0 {
+		// rounding avoids that sums of fractional scores like 0.7 + 0.2 miss a pass percentage of 90
+		result.Score = math.Round(result.AchievedScore/result.MaximumScore*100*100) / 100
+	}
+	result.Status = status(result.Score, objectives.Pass, objectives.Warning)
+
+	if e.History != nil {
+		if err := e.History.StoreResult(project, stage, service, result); err != nil {
+			return nil, fmt.Errorf("Error when storing evaluation: %s", err.Error())
+		}
+	}
+	return result, nil
+}
+
+func evaluateObjective(objective *models.ServiceObjective, criteria []*models.Criterion, values map[string]float64,
+	comparisonValues map[string]float64) *ObjectiveResult {
+
+	result := &ObjectiveResult{
+		Metric:       objective.Metric,
+		Criteria:     objective.Criteria,
+		Timeframe:    objective.Timeframe,
+		MaximumScore: models.Float32ToFloat64(objective.Score),
+	}
+	if len(objective.Criteria) == 0 {
+		result.Threshold = models.Float32ToFloat64(objective.Threshold)
+	}
+	comparisonValue, hasComparisonValue := comparisonValues[objective.Metric]
+	for _, criterion := range criteria {
+		if criterion.Relative && hasComparisonValue {
+			result.ComparisonValue = &comparisonValue
+		}
+	}
+
+	value, ok := values[objective.Metric]
+	if !ok {
+		result.Message = "no value measured"
+		return result
+	}
+	result.Value = &value
+
+	violations := []string{}
+	for _, criterion := range criteria {
+		if criterion.Relative && !hasComparisonValue {
+			continue
+		}
+		if !criterion.Matches(value, comparisonValue) {
+			violations = append(violations, fmt.Sprintf("value %g does not meet criterion %s (limit %g)", value, criterion, criterion.Limit(comparisonValue)))
+		}
+	}
+	if len(violations) > 0 {
+		result.Message = strings.Join(violations, "; ")
+		return result
+	}
+	result.Passed = true
+	result.Score = result.MaximumScore
+	return result
+}
+
+// averageValues returns the average measured value of each metric in the results
+func averageValues(results []*Result) map[string]float64 {
+	sums := map[string]float64{}
+	counts := map[string]int{}
+	for _, result := range results {
+		for _, objective := range result.Objectives {
+			if objective.Value != nil {
+				sums[objective.Metric] += *objective.Value
+				counts[objective.Metric]++
+			}
+		}
+	}
+	averages := map[string]float64{}
+	for metric, sum := range sums {
+		averages[metric] = sum / float64(counts[metric])
+	}
+	return averages
+}
+
+func status(score float64, pass int, warning int) string {
+	if score >= float64(pass) {
+		return StatusPass
+	}
+	if score >= float64(warning) {
+		return StatusWarning
+	}
+	return StatusFail
+}
+
+func checkIndicators(indicators *models.ServiceIndicators, objectives *models.ServiceObjectives) error {
+	defined := map[string]bool{}
+	for _, indicator := range indicators.Indicators {
+		defined[indicator.Metric] = true
+	}
+	for _, objective := range objectives.Objectives {
+		if !defined[objective.Metric] {
+			return fmt.Errorf("Error when evaluating objectives: objective %s refers to an undefined indicator", objective.Metric)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package evaluation_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/evaluation"
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		objectives  *models.ServiceObjectives
+		values      map[string]float64
+		wantStatus  string
+		wantScore   float64
+		wantPassed  []bool
+		wantMessage string
+	}{
+		{
+			name: "all objectives passed",
+			objectives: &models.ServiceObjectives{Pass: 90, Warning: 75, Objectives: []*models.ServiceObjective{
+				{Metric: "request_latency_seconds", Threshold: 0.8, Score: 50},
+				{Metric: "error_rate", Criteria: []string{"<1", ">=0"}, Score: 50},
+			}},
+			values:     map[string]float64{"request_latency_seconds": 0.8, "error_rate": 0.5},
+			wantStatus: evaluation.StatusPass,
+			wantScore:  100,
+			wantPassed: []bool{true, true},
+		},
+		{
+			name: "warning",
+			objectives: &models.ServiceObjectives{Pass: 90, Warning: 50, Objectives: []*models.ServiceObjective{
+				{Metric: "request_latency_seconds", Threshold: 0.8, Score: 1},
+				{Metric: "error_rate", Criteria: []string{"<1"}, Score: 1},
+				{Metric: "throughput", Criteria: []string{">100"}, Score: 1},
+			}},
+			values:      map[string]float64{"request_latency_seconds": 0.9, "error_rate": 0.5, "throughput": 200},
+			wantStatus:  evaluation.StatusWarning,
+			wantScore:   66.67,
+			wantPassed:  []bool{false, true, true},
+			wantMessage: "value 0.9 does not meet criterion <=0.8",
+		},
+		{
+			name: "fail",
+			objectives: &models.ServiceObjectives{Pass: 90, Warning: 50, Objectives: []*models.ServiceObjective{
+				{Metric: "request_latency_seconds", Threshold: 0.8, Score: 1},
+				{Metric: "error_rate", Criteria: []string{"<1"}, Score: 1},
+				{Metric: "throughput", Criteria: []string{">100"}, Score: 1},
+			}},
+			values:      map[string]float64{"request_latency_seconds": 0.9, "error_rate": 1, "throughput": 200},
+			wantStatus:  evaluation.StatusFail,
+			wantScore:   33.33,
+			wantPassed:  []bool{false, false, true},
+			wantMessage: "value 0.9 does not meet criterion <=0.8",
+		},
+		{
+			name: "missing value",
+			objectives: &models.ServiceObjectives{Pass: 90, Warning: 50, Objectives: []*models.ServiceObjective{
+				{Metric: "request_latency_seconds", Threshold: 0.8, Score: 1},
+			}},
+			values:      map[string]float64{},
+			wantStatus:  evaluation.StatusFail,
+			wantScore:   0,
+			wantPassed:  []bool{false},
+			wantMessage: "no value measured",
+		},
+		{
+			// 0.7 + 0.2 is 0.8999999999999999 in floating point arithmetic
+			name: "total score rounding",
+			objectives: &models.ServiceObjectives{Pass: 90, Warning: 50, Objectives: []*models.ServiceObjective{
+				{Metric: "request_latency_seconds", Threshold: 0.8, Score: 0.7},
+				{Metric: "error_rate", Threshold: 1, Score: 0.2},
+				{Metric: "throughput", Criteria: []string{">100"}, Score: 0.1},
+			}},
+			values:      map[string]float64{"request_latency_seconds": 0.5, "error_rate": 0, "throughput": 50},
+			wantStatus:  evaluation.StatusPass,
+			wantScore:   90,
+			wantPassed:  []bool{true, true, false},
+			wantMessage: "value 50 does not meet criterion >100",
+		},
+		{
+			name:       "no objectives",
+			objectives: &models.ServiceObjectives{Pass: 90, Warning: 50},
+			values:     map[string]float64{},
+			wantStatus: evaluation.StatusPass,
+			wantScore:  100,
+			wantPassed: []bool{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluation.Evaluate(nil, tt.objectives, tt.values)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Status != tt.wantStatus || result.Score != tt.wantScore {
+				t.Errorf("got status %s with score %g, want %s with %g", result.Status, result.Score, tt.wantStatus, tt.wantScore)
+			}
+			if result.Pass != tt.objectives.Pass || result.Warning != tt.objectives.Warning {
+				t.Errorf("got pass %d and warning %d, want the percentages of the objectives", result.Pass, result.Warning)
+			}
+			if len(result.Objectives) != len(tt.wantPassed) {
+				t.Fatalf("got %d objective results, want %d", len(result.Objectives), len(tt.wantPassed))
+			}
+			messages := []string{}
+			for i, objective := range result.Objectives {
+				if objective.Passed != tt.wantPassed[i] {
+					t.Errorf("got passed %t for objective %s, want %t", objective.Passed, objective.Metric, tt.wantPassed[i])
+				}
+				if objective.Passed && objective.Score != objective.MaximumScore || !objective.Passed && objective.Score != 0 {
+					t.Errorf("got score %g of %g for objective %s, want the maximum score only if it passed", objective.Score, objective.MaximumScore, objective.Metric)
+				}
+				if objective.Message != "" {
+					messages = append(messages, objective.Message)
+				}
+			}
+			if got := strings.Join(messages, "; "); !strings.Contains(got, tt.wantMessage) || (tt.wantMessage == "") != (got == "") {
+				t.Errorf("got messages %q, want %q", got, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	indicators := &models.ServiceIndicators{Indicators: []*models.ServiceIndicator{{Metric: "request_latency_seconds"}}}
+	tests := []struct {
+		name       string
+		indicators *models.ServiceIndicators
+		objectives *models.ServiceObjectives
+		wantErr    string
+	}{
+		{name: "no objectives", wantErr: "no objectives defined"},
+		{
+			name:       "warning exceeds pass",
+			objectives: &models.ServiceObjectives{Pass: 50, Warning: 90},
+			wantErr:    "warning percentage 90 exceeds pass percentage 50",
+		},
+		{
+			name:       "undefined indicator",
+			indicators: indicators,
+			objectives: &models.ServiceObjectives{Pass: 90, Objectives: []*models.ServiceObjective{{Metric: "error_rate", Score: 1}}},
+			wantErr:    "objective error_rate refers to an undefined indicator",
+		},
+		{
+			name:       "invalid criterion",
+			objectives: &models.ServiceObjectives{Pass: 90, Objectives: []*models.ServiceObjective{{Metric: "error_rate", Criteria: []string{"~1"}, Score: 1}}},
+			wantErr:    "invalid criterion '~1'",
+		},
+		{
+			name:       "relative criterion without history",
+			objectives: &models.ServiceObjectives{Pass: 90, Objectives: []*models.ServiceObjective{{Metric: "error_rate", Criteria: []string{"<=+10%"}, Score: 1}}},
+			wantErr:    "relative criteria require a history",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := evaluation.Evaluate(tt.indicators, tt.objectives, map[string]float64{"request_latency_seconds": 1, "error_rate": 1})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluatorComparesAgainstHistory(t *testing.T) {
+	objectives := &models.ServiceObjectives{
+		Pass:    90,
+		Warning: 50,
+		Objectives: []*models.ServiceObjective{
+			{Metric: "request_latency_seconds", Criteria: []string{"<=+10%", "<1000"}, Score: 1},
+		},
+		Comparison: &models.ServiceObjectivesComparison{NumberOfComparisonResults: 2},
+	}
+
+	// each evaluation is compared against the average of the last two passed evaluations before it
+	evaluations := []struct {
+		value          float64
+		wantStatus     string
+		wantComparison *float64
+	}{
+		// without history the first evaluation establishes the baseline
+		{value: 500, wantStatus: evaluation.StatusPass},
+		{value: 540, wantStatus: evaluation.StatusPass, wantComparison: float64Ptr(500)},
+		// the failed evaluation is not compared against later on
+		{value: 900, wantStatus: evaluation.StatusFail, wantComparison: float64Ptr(520)},
+		{value: 570, wantStatus: evaluation.StatusPass, wantComparison: float64Ptr(520)},
+		{value: 560, wantStatus: evaluation.StatusPass, wantComparison: float64Ptr(555)},
+		// absolute criteria still apply
+		{value: 1000, wantStatus: evaluation.StatusFail, wantComparison: float64Ptr(565)},
+	}
+
+	evaluator := evaluation.NewEvaluator(evaluation.NewMemoryHistoryStore())
+	for i, e := range evaluations {
+		result, err := evaluator.Evaluate("sockshop", "dev", "carts", nil, objectives, map[string]float64{"request_latency_seconds": e.value})
+		if err != nil {
+			t.Fatalf("evaluation %d: unexpected error: %v", i+1, err)
+		}
+		if result.Status != e.wantStatus {
+			t.Errorf("evaluation %d: got status %s, want %s: %s", i+1, result.Status, e.wantStatus, result.Objectives[0].Message)
+		}
+		got := result.Objectives[0].ComparisonValue
+		if (got == nil) != (e.wantComparison == nil) || got != nil && *got != *e.wantComparison {
+			t.Errorf("evaluation %d: got comparison value %v, want %v", i+1, formatFloat64Ptr(got), formatFloat64Ptr(e.wantComparison))
+		}
+	}
+
+	// the history of another service is not compared against
+	result, err := evaluator.Evaluate("sockshop", "dev", "orders", nil, objectives, map[string]float64{"request_latency_seconds": 900})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != evaluation.StatusPass || result.Objectives[0].ComparisonValue != nil {
+		t.Errorf("got status %s with comparison value %s for a service without history, want pass without comparison", result.Status, formatFloat64Ptr(result.Objectives[0].ComparisonValue))
+	}
+}
+
+func TestMemoryHistoryStore(t *testing.T) {
+	store := evaluation.NewMemoryHistoryStore()
+	statuses := []string{evaluation.StatusPass, evaluation.StatusFail, evaluation.StatusPass, evaluation.StatusWarning, evaluation.StatusPass}
+	for i, status := range statuses {
+		if err := store.StoreResult("sockshop", "dev", "carts", &evaluation.Result{Status: status, Score: float64(i)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		service    string
+		n          int
+		wantScores []float64
+	}{
+		{name: "most recent first", service: "carts", n: 2, wantScores: []float64{4, 2}},
+		{name: "fewer passed results than requested", service: "carts", n: 5, wantScores: []float64{4, 2, 0}},
+		{name: "no history", service: "orders", n: 2, wantScores: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.GetPassedResults("sockshop", "dev", tt.service, tt.n)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			scores := []float64{}
+			for _, result := range results {
+				scores = append(scores, result.Score)
+			}
+			if len(scores) != len(tt.wantScores) {
+				t.Fatalf("got results with scores %v, want %v", scores, tt.wantScores)
+			}
+			for i := range scores {
+				if scores[i] != tt.wantScores[i] {
+					t.Errorf("got results with scores %v, want %v", scores, tt.wantScores)
+					break
+				}
+			}
+		})
+	}
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func formatFloat64Ptr(f *float64) string {
+	if f == nil {
+		return "none"
+	}
+	return strconv.FormatFloat(*f, 'g', -1, 64)
+}
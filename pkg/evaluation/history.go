@@ -0,0 +1,55 @@
+package evaluation
+
+import (
+	"sync"
+)
+
+// HistoryStore stores the results of evaluations, so relative criteria of later evaluations can be compared against them
+type HistoryStore interface {
+	// GetPassedResults returns the results of the last n passed evaluations of a service, most recent first
+	GetPassedResults(project string, stage string, service string, n int) ([]*Result, error)
+	// StoreResult stores the result of an evaluation of a service
+	StoreResult(project string, stage string, service string, result *Result) error
+}
+
+// MemoryHistoryStore keeps the results of evaluations in memory
+type MemoryHistoryStore struct {
+	mu      sync.Mutex
+	results map[string][]*Result
+}
+
+// NewMemoryHistoryStore returns a new MemoryHistoryStore
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{
+		results: map[string][]*Result{},
+	}
+}
+
+// GetPassedResults returns the results of the last n passed evaluations of a service, most recent first
+func (s *MemoryHistoryStore) GetPassedResults(project string, stage string, service string, n int) ([]*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	passed := []*Result{}
+	results := s.results[historyKey(project, stage, service)]
+	for i := len(results) - 1; i >= 0 && len(passed) < n; i-- {
+		if results[i].Status == StatusPass {
+			passed = append(passed, results[i])
+		}
+	}
+	return passed, nil
+}
+
+// StoreResult stores the result of an evaluation of a service
+func (s *MemoryHistoryStore) StoreResult(project string, stage string, service string, result *Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := historyKey(project, stage, service)
+	s.results[key] = append(s.results[key], result)
+	return nil
+}
+
+func historyKey(project string, stage string, service string) string {
+	return project + "/" + stage + "/" + service
+}
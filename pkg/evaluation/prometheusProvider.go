@@ -0,0 +1,124 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SourcePrometheus is the source of service indicators measured by Prometheus
+const SourcePrometheus = "prometheus"
+
+// PrometheusProvider measures service indicators with instant queries against the HTTP API of Prometheus.
+// The query is evaluated at the end of the time range, so range vectors should use $DURATION_SECONDS, e.g.
+//
+//	histogram_quantile(0.95, sum(rate(http_request_duration_seconds_bucket{job="$SERVICE-$PROJECT-$STAGE"}[$DURATION_SECONDS])) by (le))
+type PrometheusProvider struct {
+	// URL is the URL of the Prometheus server, e.g. http://prometheus-service.monitoring.svc.cluster.local:8080
+	URL string
+	// HTTPClient is used for sending the queries. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// NewPrometheusProvider returns a new PrometheusProvider querying the Prometheus server at the URL
+func NewPrometheusProvider(prometheusURL string) *PrometheusProvider {
+	return &PrometheusProvider{
+		URL: prometheusURL,
+	}
+}
+
+type prometheusResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type prometheusSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// GetSLIValue runs the query against Prometheus. The query must return a scalar or a vector with a single sample.
+func (p *PrometheusProvider) GetSLIValue(ctx context.Context, query SLIQuery) (float64, error) {
+	if query.Query == "" {
+		return 0, fmt.Errorf("Error when querying Prometheus: no query defined for %s", query.Metric)
+	}
+	params := url.Values{}
+	params.Set("query", query.Query)
+	if !query.End.IsZero() {
+		params.Set("time", strconv.FormatInt(query.End.Unix(), 10))
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(p.URL, "/")+"/api/v1/query?"+params.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("Error when querying Prometheus: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("Error when querying Prometheus: %s", err.Error())
+	}
+	var result prometheusResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("Error when querying Prometheus: status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if result.Status != "success" {
+		return 0, fmt.Errorf("Error when querying Prometheus: %s: %s", result.ErrorType, result.Error)
+	}
+	return parsePrometheusResult(result.Data.ResultType, result.Data.Result)
+}
+
+func parsePrometheusResult(resultType string, result json.RawMessage) (float64, error) {
+	switch resultType {
+	case "scalar":
+		var value []interface{}
+		if err := json.Unmarshal(result, &value); err != nil {
+			return 0, fmt.Errorf("Error when parsing Prometheus result: %s", err.Error())
+		}
+		return parsePrometheusValue(value)
+	case "vector":
+		var samples []prometheusSample
+		if err := json.Unmarshal(result, &samples); err != nil {
+			return 0, fmt.Errorf("Error when parsing Prometheus result: %s", err.Error())
+		}
+		if len(samples) != 1 {
+			return 0, fmt.Errorf("Error when parsing Prometheus result: expected a single sample but got %d", len(samples))
+		}
+		return parsePrometheusValue(samples[0].Value)
+	}
+	return 0, fmt.Errorf("Error when parsing Prometheus result: unsupported result type '%s'", resultType)
+}
+
+// parsePrometheusValue parses a value of the form [<unix time>, "<value>"]
+func parsePrometheusValue(value []interface{}) (float64, error) {
+	if len(value) != 2 {
+		return 0, fmt.Errorf("Error when parsing Prometheus result: invalid value %v", value)
+	}
+	s, ok := value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("Error when parsing Prometheus result: invalid value %v", value)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Error when parsing Prometheus result: %s", err.Error())
+	}
+	return f, nil
+}
@@ -0,0 +1,135 @@
+package evaluation_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/evaluation"
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+func TestPrometheusProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		status    int
+		response  string
+		wantQuery string
+		want      float64
+		wantErr   string
+	}{
+		{
+			name:      "vector",
+			query:     `histogram_quantile(0.95, sum(rate(http_request_duration_seconds_bucket{job="$SERVICE-$PROJECT-$STAGE"}[$DURATION_SECONDS])) by (le))`,
+			status:    http.StatusOK,
+			response:  `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"carts-sockshop-dev"},"value":[1000,"0.42"]}]}}`,
+			wantQuery: `histogram_quantile(0.95, sum(rate(http_request_duration_seconds_bucket{job="carts-sockshop-dev"}[300s])) by (le))`,
+			want:      0.42,
+		},
+		{
+			name:      "scalar",
+			query:     `scalar(up{deployment="$DEPLOYMENT"})`,
+			status:    http.StatusOK,
+			response:  `{"status":"success","data":{"resultType":"scalar","result":[1000,"3"]}}`,
+			wantQuery: `scalar(up{deployment="canary"})`,
+			want:      3,
+		},
+		{
+			name:     "empty result",
+			query:    "up",
+			status:   http.StatusOK,
+			response: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			wantErr:  "expected a single sample but got 0",
+		},
+		{
+			name:     "several samples",
+			query:    "up",
+			status:   http.StatusOK,
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"value":[1000,"1"]},{"value":[1000,"2"]}]}}`,
+			wantErr:  "expected a single sample but got 2",
+		},
+		{
+			name:     "unsupported result type",
+			query:    "up[5m]",
+			status:   http.StatusOK,
+			response: `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			wantErr:  "unsupported result type 'matrix'",
+		},
+		{
+			name:     "query error",
+			query:    "up{",
+			status:   http.StatusBadRequest,
+			response: `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			wantErr:  "bad_data: parse error",
+		},
+		{
+			name:     "response without JSON body",
+			query:    "up",
+			status:   http.StatusServiceUnavailable,
+			response: "service unavailable",
+			wantErr:  "status code 503: service unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath, gotQuery, gotTime string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				gotQuery = r.URL.Query().Get("query")
+				gotTime = r.URL.Query().Get("time")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.response)
+			}))
+			defer srv.Close()
+
+			end := time.Unix(1000, 0)
+			query := evaluation.NewSLIQuery(&models.ServiceIndicator{Metric: "response_time_p95", Source: evaluation.SourcePrometheus, Query: tt.query}, evaluation.QueryParameters{
+				Project:    "sockshop",
+				Stage:      "dev",
+				Service:    "carts",
+				Deployment: "canary",
+				Start:      end.Add(-5 * time.Minute),
+				End:        end,
+			})
+			got, err := evaluation.NewPrometheusProvider(srv.URL+"/").GetSLIValue(context.Background(), query)
+
+			if gotPath != "/api/v1/query" {
+				t.Errorf("got path %s, want /api/v1/query", gotPath)
+			}
+			if gotTime != "1000" {
+				t.Errorf("got time %s, want 1000", gotTime)
+			}
+			if tt.wantQuery != "" && gotQuery != tt.wantQuery {
+				t.Errorf("got query %s, want %s", gotQuery, tt.wantQuery)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got error %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got value %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrometheusProviderWithoutQuery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("got request %s, want none", r.URL)
+	}))
+	defer srv.Close()
+
+	if _, err := evaluation.NewPrometheusProvider(srv.URL).GetSLIValue(context.Background(), evaluation.SLIQuery{Metric: "throughput"}); err == nil {
+		t.Error("got no error, want an error for the missing query")
+	}
+}
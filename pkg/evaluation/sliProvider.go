@@ -0,0 +1,119 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+// QueryParameters are the parameters the queries of service indicators are run with
+type QueryParameters struct {
+	Project    string
+	Stage      string
+	Service    string
+	Deployment string
+	// Start and End define the time range the indicators are measured for
+	Start time.Time
+	End   time.Time
+}
+
+// SLIQuery is the query of a service indicator with all placeholders replaced
+type SLIQuery struct {
+	Metric      string
+	Query       string
+	QueryObject map[string]string
+	Start       time.Time
+	End         time.Time
+}
+
+// SLIProvider measures service indicators by running their queries against a data source, e.g. Prometheus
+type SLIProvider interface {
+	// GetSLIValue runs the query and returns the measured value
+	GetSLIValue(ctx context.Context, query SLIQuery) (float64, error)
+}
+
+// NewSLIQuery returns the query of the service indicator for the parameters. The placeholders $PROJECT, $STAGE,
+// $SERVICE, $DEPLOYMENT and $DURATION_SECONDS are replaced in the query and in the values of the query object.
+func NewSLIQuery(indicator *models.ServiceIndicator, params QueryParameters) SLIQuery {
+	replacer := strings.NewReplacer(
+		"$PROJECT", params.Project,
+		"$STAGE", params.Stage,
+		"$SERVICE", params.Service,
+		"$DEPLOYMENT", params.Deployment,
+		"$DURATION_SECONDS", strconv.FormatInt(int64(params.End.Sub(params.Start).Seconds()), 10)+"s",
+	)
+	query := SLIQuery{
+		Metric:      indicator.Metric,
+		Query:       replacer.Replace(indicator.Query),
+		QueryObject: map[string]string{},
+		Start:       params.Start,
+		End:         params.End,
+	}
+	for _, object := range indicator.QueryObject {
+		query.QueryObject[object.Key] = replacer.Replace(object.Value)
+	}
+	return query
+}
+
+// SLIProviders selects the SLIProvider for the source of a service indicator
+type SLIProviders struct {
+	mu        sync.RWMutex
+	providers map[string]SLIProvider
+}
+
+// NewSLIProviders returns a new SLIProviders instance without registered providers
+func NewSLIProviders() *SLIProviders {
+	return &SLIProviders{
+		providers: map[string]SLIProvider{},
+	}
+}
+
+// Register registers the provider for service indicators with the provided source. Sources are case-insensitive.
+func (p *SLIProviders) Register(source string, provider SLIProvider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.providers[strings.ToLower(source)] = provider
+}
+
+// Get returns the provider registered for the source
+func (p *SLIProviders) Get(source string) (SLIProvider, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	provider, ok := p.providers[strings.ToLower(source)]
+	if !ok {
+		return nil, fmt.Errorf("No SLI provider registered for source %s", source)
+	}
+	return provider, nil
+}
+
+// GetSLIValues measures all service indicators with the providers registered for their sources. The values of
+// the indicators measured successfully are returned, even if other indicators failed, together with an error
+// describing the failed indicators. The values can be passed to Evaluate.
+func (p *SLIProviders) GetSLIValues(ctx context.Context, indicators *models.ServiceIndicators, params QueryParameters) (map[string]float64, error) {
+	values := map[string]float64{}
+	problems := []string{}
+	for _, indicator := range indicators.Indicators {
+		provider, err := p.Get(indicator.Source)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", indicator.Metric, err.Error()))
+			continue
+		}
+		value, err := provider.GetSLIValue(ctx, NewSLIQuery(indicator, params))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", indicator.Metric, err.Error()))
+			continue
+		}
+		values[indicator.Metric] = value
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return values, fmt.Errorf("Error when measuring service indicators: %s", strings.Join(problems, "; "))
+	}
+	return values, nil
+}
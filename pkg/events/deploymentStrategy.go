@@ -3,7 +3,10 @@ package events
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"strings"
+
+	"github.com/keptn/go-utils/pkg/models"
 )
 
 // DeploymentStrategy describes how a keptn-managed service is deployed
@@ -31,6 +34,20 @@ var deploymentStrategyToID = map[string]DeploymentStrategy{
 	"duplicate": Duplicate,
 }
 
+var shipyardDeploymentStrategyToID = map[string]DeploymentStrategy{
+	models.DeploymentStrategyDirect:           Direct,
+	models.DeploymentStrategyBlueGreenService: Duplicate,
+}
+
+// GetDeploymentStrategy maps the deployment strategy of a shipyard stage to a DeploymentStrategy
+func GetDeploymentStrategy(shipyardStrategy string) (DeploymentStrategy, error) {
+	strategy, ok := shipyardDeploymentStrategyToID[shipyardStrategy]
+	if !ok {
+		return Direct, fmt.Errorf("Unknown deployment strategy '%s'", shipyardStrategy)
+	}
+	return strategy, nil
+}
+
 // MarshalJSON marshals the enum as a quoted json string
 func (s DeploymentStrategy) MarshalJSON() ([]byte, error) {
 	buffer := bytes.NewBufferString(`"`)
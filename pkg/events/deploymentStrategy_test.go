@@ -0,0 +1,27 @@
+package events
+
+import "testing"
+
+func TestGetDeploymentStrategy(t *testing.T) {
+	tests := []struct {
+		shipyardStrategy string
+		want             DeploymentStrategy
+		wantErr          bool
+	}{
+		{shipyardStrategy: "direct", want: Direct},
+		{shipyardStrategy: "blue_green_service", want: Duplicate},
+		{shipyardStrategy: "duplicate", want: Direct, wantErr: true},
+		{shipyardStrategy: "", want: Direct, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shipyardStrategy, func(t *testing.T) {
+			got, err := GetDeploymentStrategy(tt.shipyardStrategy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got strategy %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package events
 
-import "github.com/keptn/go-utils/pkg/models"
+import (
+	b64 "encoding/base64"
+	"fmt"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
 
 // ServiceCreateEventType is a CloudEvent type for creating a new service
 const ServiceCreateEventType = "sh.keptn.event.service.create"
@@ -46,6 +51,15 @@ type ProjectCreateEventData struct {
 	GitRemoteURL string `json:"gitRemoteURL,omitempty"`
 }
 
+// GetShipyard decodes the shipyard of the event. Shipyards of older versions are converted to the current model.
+func (e *ProjectCreateEventData) GetShipyard() (*models.Shipyard, error) {
+	data, err := b64.StdEncoding.DecodeString(e.Shipyard)
+	if err != nil {
+		return nil, fmt.Errorf("Error when decoding shipyard: %s", err.Error())
+	}
+	return models.ParseShipyard(data)
+}
+
 // ProjectDeleteEventData represents the data for deleting a new project
 type ProjectDeleteEventData struct {
 	// Project is the name of the project
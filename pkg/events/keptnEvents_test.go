@@ -0,0 +1,29 @@
+package events
+
+import (
+	b64 "encoding/base64"
+	"testing"
+)
+
+func TestProjectCreateEventDataGetShipyard(t *testing.T) {
+	// like KeptnHandler.GetShipyard, the shipyard of the event is migrated but not validated
+	data := ProjectCreateEventData{Shipyard: b64.StdEncoding.EncodeToString([]byte(`stages:
+- name: dev
+- name: prod
+  deployment_strategy: canary
+`))}
+	shipyard, err := data.GetShipyard()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(shipyard.Stages) != 2 || shipyard.Stages[1].DeploymentStrategy != "canary" {
+		t.Errorf("got stages %+v, want dev and prod", shipyard.Stages)
+	}
+
+	if _, err := (&ProjectCreateEventData{Shipyard: "not base64"}).GetShipyard(); err == nil {
+		t.Error("got no error for an invalid encoding, want an error")
+	}
+	if _, err := (&ProjectCreateEventData{Shipyard: b64.StdEncoding.EncodeToString([]byte("apiVersion: keptn.sh/v9\n"))}).GetShipyard(); err == nil {
+		t.Error("got no error for an unsupported apiVersion, want an error")
+	}
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Operators of a criterion
+const (
+	OperatorLess           = "<"
+	OperatorLessOrEqual    = "<="
+	OperatorEqual          = "="
+	OperatorGreaterOrEqual = ">="
+	OperatorGreater        = ">"
+)
+
+var criterionRegex = regexp.MustCompile(`^\s*(<=|>=|<|>|=)\s*([+-])?(\d+(?:\.\d*)?|\.\d+)\s*(%)?\s*$`)
+
+// Criterion is a parsed criterion of a service objective. Criteria are written as operator followed by a value:
+//
+//	<600     the value is less than 600
+//	<=+10%   the value increased by at most 10 percent compared to earlier evaluations
+//	<=+50    the value increased by at most 50 compared to earlier evaluations
+//	>=-5%    the value decreased by at most 5 percent compared to earlier evaluations
+//	>=-5     the value decreased by at most 5 compared to earlier evaluations
+//
+// The sign of the value marks a criterion as relative, so absolute limits are written without a sign and
+// cannot be negative. A percentage requires a sign, as "<5%" would be ambiguous.
+type Criterion struct {
+	Operator string
+	// Value is the limit, or the change relative to the comparison value if Relative is set
+	Value float64
+	// Relative is set if the value is compared against the values of earlier evaluations
+	Relative bool
+	// Percent is set if Value is a change in percent of the comparison value
+	Percent bool
+}
+
+// ParseCriterion parses a criterion like "<600" or "<=+10%"
+func ParseCriterion(criterion string) (*Criterion, error) {
+	match := criterionRegex.FindStringSubmatch(criterion)
+	if match == nil {
+		return nil, fmt.Errorf("invalid criterion '%s'", criterion)
+	}
+	value, err := strconv.ParseFloat(match[3], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid criterion '%s': %s", criterion, err.Error())
+	}
+	if match[2] == "" && match[4] != "" {
+		return nil, fmt.Errorf("invalid criterion '%s': a percentage must be signed, e.g. +%s%%", criterion, match[3])
+	}
+	if match[2] == "-" {
+		value = -value
+	}
+	return &Criterion{
+		Operator: match[1],
+		Value:    value,
+		Relative: match[2] != "",
+		Percent:  match[4] != "",
+	}, nil
+}
+
+// Limit returns the value the measured value is compared against. For relative criteria the limit is
+// derived from the comparison value.
+func (c *Criterion) Limit(comparisonValue float64) float64 {
+	if !c.Relative {
+		return c.Value
+	}
+	if c.Percent {
+		return comparisonValue + comparisonValue*c.Value/100
+	}
+	return comparisonValue + c.Value
+}
+
+// Matches returns whether the value meets the criterion
+func (c *Criterion) Matches(value float64, comparisonValue float64) bool {
+	limit := c.Limit(comparisonValue)
+	switch c.Operator {
+	case OperatorLess:
+		return value < limit
+	case OperatorLessOrEqual:
+		return value <= limit
+	case OperatorEqual:
+		return value == limit
+	case OperatorGreaterOrEqual:
+		return value >= limit
+	case OperatorGreater:
+		return value > limit
+	}
+	return false
+}
+
+// String returns the criterion in the notation it is parsed from
+func (c *Criterion) String() string {
+	value := strconv.FormatFloat(c.Value, 'g', -1, 64)
+	if c.Relative && c.Value >= 0 {
+		value = "+" + value
+	}
+	if c.Percent {
+		value += "%"
+	}
+	return c.Operator + value
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCriterion(t *testing.T) {
+	tests := []struct {
+		criterion string
+		want      Criterion
+		wantErr   string
+	}{
+		{criterion: "<600", want: Criterion{Operator: OperatorLess, Value: 600}},
+		{criterion: " <= 0.5 ", want: Criterion{Operator: OperatorLessOrEqual, Value: 0.5}},
+		{criterion: "=.5", want: Criterion{Operator: OperatorEqual, Value: 0.5}},
+		{criterion: ">=0", want: Criterion{Operator: OperatorGreaterOrEqual, Value: 0}},
+		{criterion: "<=+10%", want: Criterion{Operator: OperatorLessOrEqual, Value: 10, Relative: true, Percent: true}},
+		{criterion: "<=+50", want: Criterion{Operator: OperatorLessOrEqual, Value: 50, Relative: true}},
+		{criterion: ">=-5%", want: Criterion{Operator: OperatorGreaterOrEqual, Value: -5, Relative: true, Percent: true}},
+		// the sign marks the criterion as relative, absolute limits are unsigned
+		{criterion: ">=-5", want: Criterion{Operator: OperatorGreaterOrEqual, Value: -5, Relative: true}},
+		{criterion: ">+0", want: Criterion{Operator: OperatorGreater, Value: 0, Relative: true}},
+		{criterion: "<5%", wantErr: "a percentage must be signed"},
+		{criterion: "600", wantErr: "invalid criterion '600'"},
+		{criterion: "<", wantErr: "invalid criterion '<'"},
+		{criterion: "=<600", wantErr: "invalid criterion '=<600'"},
+		{criterion: "<=10%%", wantErr: "invalid criterion '<=10%%'"},
+		{criterion: "<+-5", wantErr: "invalid criterion '<+-5'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.criterion, func(t *testing.T) {
+			got, err := ParseCriterion(tt.criterion)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got criterion %+v and error %v, want %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("got criterion %+v, want %+v", *got, tt.want)
+			}
+			reparsed, err := ParseCriterion(got.String())
+			if err != nil || *reparsed != *got {
+				t.Errorf("got criterion %+v and error %v after parsing %s again, want %+v", reparsed, err, got, *got)
+			}
+		})
+	}
+}
+
+func TestCriterionMatches(t *testing.T) {
+	tests := []struct {
+		criterion       string
+		value           float64
+		comparisonValue float64
+		wantLimit       float64
+		want            bool
+	}{
+		{criterion: "<600", value: 599, comparisonValue: 1000, wantLimit: 600, want: true},
+		{criterion: "<600", value: 600, wantLimit: 600, want: false},
+		{criterion: "<=600", value: 600, wantLimit: 600, want: true},
+		{criterion: "=1", value: 1, wantLimit: 1, want: true},
+		{criterion: ">1", value: 1, wantLimit: 1, want: false},
+		{criterion: "<=+10%", value: 220, comparisonValue: 200, wantLimit: 220, want: true},
+		{criterion: "<=+10%", value: 221, comparisonValue: 200, wantLimit: 220, want: false},
+		{criterion: "<=+50", value: 251, comparisonValue: 200, wantLimit: 250, want: false},
+		{criterion: ">=-5%", value: 190, comparisonValue: 200, wantLimit: 190, want: true},
+		{criterion: ">=-5", value: 194, comparisonValue: 200, wantLimit: 195, want: false},
+	}
+	for _, tt := range tests {
+		criterion, err := ParseCriterion(tt.criterion)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := criterion.Limit(tt.comparisonValue); got != tt.wantLimit {
+			t.Errorf("got limit %g of %s compared to %g, want %g", got, tt.criterion, tt.comparisonValue, tt.wantLimit)
+		}
+		if got := criterion.Matches(tt.value, tt.comparisonValue); got != tt.want {
+			t.Errorf("got %t for %g matching %s compared to %g, want %t", got, tt.value, tt.criterion, tt.comparisonValue, tt.want)
+		}
+	}
+}
+
+func TestParseServiceObjectives(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "threshold",
+			data: `pass: 90
+warning: 75
+objectives:
+- metric: request_latency_seconds
+  threshold: 0.8
+  timeframe: 5m
+  score: 50
+`,
+		},
+		{
+			name: "criteria",
+			data: `pass: 90
+warning: 75
+comparison:
+  numberOfComparisonResults: 3
+objectives:
+- metric: request_latency_seconds
+  criteria: ["<=+10%", "<1"]
+  score: 50
+`,
+		},
+		{
+			name: "json",
+			data: `{"pass": 90, "warning": 75, "objectives": [{"metric": "error_rate", "criteria": [">=-5%"], "score": 50}]}`,
+		},
+		{
+			name: "invalid criteria",
+			data: `pass: 90
+objectives:
+- metric: request_latency_seconds
+  criteria: ["<5%"]
+- metric: error_rate
+  criteria: ["~1"]
+`,
+			wantErr: "objective request_latency_seconds: invalid criterion '<5%': a percentage must be signed, e.g. +5%; objective error_rate: invalid criterion '~1'",
+		},
+		{
+			name: "invalid comparison",
+			data: `pass: 90
+comparison:
+  numberOfComparisonResults: 0
+`,
+			wantErr: "numberOfComparisonResults must be at least 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objectives, err := ParseServiceObjectives([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if objectives.Pass != 90 || len(objectives.Objectives) != 1 || objectives.Objectives[0].Score != 50 {
+				t.Errorf("got objectives %+v, want one objective with pass 90", objectives)
+			}
+		})
+	}
+}
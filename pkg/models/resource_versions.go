@@ -0,0 +1,41 @@
+package models
+
+// VersionedResource is a resource together with the version (commit ID) it has been read at
+type VersionedResource struct {
+	Resource
+
+	// Metadata contains the version of the resource. It is nil if the configuration service did not provide it.
+	Metadata *Version `json:"metadata,omitempty"`
+}
+
+// VersionedResources is a page of resources together with their versions
+type VersionedResources struct {
+
+	// Pointer to next page
+	NextPageKey string `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize float64 `json:"pageSize,omitempty"`
+
+	// resources
+	Resources []*VersionedResource `json:"resources"`
+
+	// Total number of resources
+	TotalCount float64 `json:"totalCount,omitempty"`
+}
+
+// Versions is a page of the version history of a resource, starting with the latest version
+type Versions struct {
+
+	// Pointer to next page
+	NextPageKey string `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize float64 `json:"pageSize,omitempty"`
+
+	// versions
+	Versions []*Version `json:"versions"`
+
+	// Total number of versions
+	TotalCount float64 `json:"totalCount,omitempty"`
+}
@@ -1,10 +1,20 @@
 package models
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
 // ServiceObjectives describes objectives for a service
 type ServiceObjectives struct {
 	Pass       int                 `json:"pass" yaml:"pass"`
 	Warning    int                 `json:"warning" yaml:"warning"`
 	Objectives []*ServiceObjective `json:"objectives" yaml:"objectives"`
+	// Comparison defines the earlier evaluations relative criteria are compared against
+	Comparison *ServiceObjectivesComparison `json:"comparison,omitempty" yaml:"comparison,omitempty"`
 }
 
 // ServiceObjective describes a service objective
@@ -13,4 +23,68 @@ type ServiceObjective struct {
 	Threshold float32 `json:"threshold" yaml:"threshold"`
 	Timeframe string  `json:"timeframe" yaml:"timeframe"`
 	Score     float32 `json:"score" yaml:"score"`
+	// Criteria which all have to be met, e.g. "<600" or "<=+10%". If set, Threshold is ignored.
+	Criteria []string `json:"criteria,omitempty" yaml:"criteria,omitempty"`
+}
+
+// ServiceObjectivesComparison defines the earlier evaluations relative criteria are compared against
+type ServiceObjectivesComparison struct {
+	// NumberOfComparisonResults is the number of the last passed evaluations whose average is used as comparison value
+	NumberOfComparisonResults int `json:"numberOfComparisonResults" yaml:"numberOfComparisonResults"`
+}
+
+// DefaultNumberOfComparisonResults is the number of passed evaluations compared against if no comparison is defined
+const DefaultNumberOfComparisonResults = 1
+
+// ParseServiceObjectives parses service objectives in YAML or JSON format and checks the syntax of their criteria
+func ParseServiceObjectives(data []byte) (*ServiceObjectives, error) {
+	objectives := &ServiceObjectives{}
+	if err := yaml.Unmarshal(data, objectives); err != nil {
+		return nil, fmt.Errorf("Error when parsing service objectives: %s", err.Error())
+	}
+	problems := []string{}
+	for _, objective := range objectives.Objectives {
+		if _, err := objective.GetCriteria(); err != nil {
+			problems = append(problems, fmt.Sprintf("objective %s: %s", objective.Metric, err.Error()))
+		}
+	}
+	if objectives.Comparison != nil && objectives.Comparison.NumberOfComparisonResults < 1 {
+		problems = append(problems, "numberOfComparisonResults must be at least 1")
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("Invalid service objectives: %s", strings.Join(problems, "; "))
+	}
+	return objectives, nil
+}
+
+// GetNumberOfComparisonResults returns the number of passed evaluations relative criteria are compared against
+func (s *ServiceObjectives) GetNumberOfComparisonResults() int {
+	if s.Comparison == nil || s.Comparison.NumberOfComparisonResults < 1 {
+		return DefaultNumberOfComparisonResults
+	}
+	return s.Comparison.NumberOfComparisonResults
+}
+
+// GetCriteria returns the parsed criteria of the objective. An objective without criteria has
+// the single criterion that the value must not exceed the threshold.
+func (o *ServiceObjective) GetCriteria() ([]*Criterion, error) {
+	if len(o.Criteria) == 0 {
+		return []*Criterion{{Operator: OperatorLessOrEqual, Value: Float32ToFloat64(o.Threshold)}}, nil
+	}
+	criteria := []*Criterion{}
+	for _, c := range o.Criteria {
+		criterion, err := ParseCriterion(c)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, criterion)
+	}
+	return criteria, nil
+}
+
+// Float32ToFloat64 converts a float32 of the models to the float64 with the same decimal representation,
+// e.g. 0.8 instead of 0.800000011920929
+func Float32ToFloat64(f float32) float64 {
+	converted, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return converted
 }
@@ -1,11 +1,141 @@
 package models
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Deployment strategies of a shipyard stage
+const (
+	// DeploymentStrategyDirect deploys a new version by replacing the current one
+	DeploymentStrategyDirect = "direct"
+	// DeploymentStrategyBlueGreenService deploys a new version next to the current one and switches the traffic
+	DeploymentStrategyBlueGreenService = "blue_green_service"
+)
+
+// Test strategies of a shipyard stage
+const (
+	// TestStrategyFunctional runs functional tests
+	TestStrategyFunctional = "functional"
+	// TestStrategyPerformance runs performance tests
+	TestStrategyPerformance = "performance"
+)
+
+// Remediation strategies of a shipyard stage
+const (
+	// RemediationStrategyAutomated executes the remediation actions of a service automatically
+	RemediationStrategyAutomated = "automated"
+)
+
+var knownDeploymentStrategies = []string{DeploymentStrategyDirect, DeploymentStrategyBlueGreenService}
+
+var knownTestStrategies = []string{TestStrategyFunctional, TestStrategyPerformance}
+
+var knownRemediationStrategies = []string{RemediationStrategyAutomated}
+
 // Shipyard defines the name, deployment strategy and test strategy of each stage
 type Shipyard struct {
-	Stages []struct {
-		Name                string `json:"name" yaml:"name"`
-		DeploymentStrategy  string `json:"deployment_strategy" yaml:"deployment_strategy"`
-		TestStrategy        string `json:"test_strategy,omitempty" yaml:"test_strategy"`
-		RemediationStrategy string `json:"remediation_strategy,omitempty" yaml:"remediation_strategy"`
-	} `json:"stages" yaml:"stages"`
+	Stages []ShipyardStage `json:"stages" yaml:"stages"`
+}
+
+// ShipyardStage defines a stage of a shipyard. The order of the stages in the shipyard defines
+// the order in which artifacts are promoted.
+type ShipyardStage struct {
+	Name                string `json:"name" yaml:"name"`
+	DeploymentStrategy  string `json:"deployment_strategy" yaml:"deployment_strategy"`
+	TestStrategy        string `json:"test_strategy,omitempty" yaml:"test_strategy,omitempty"`
+	RemediationStrategy string `json:"remediation_strategy,omitempty" yaml:"remediation_strategy,omitempty"`
+}
+
+// Validate checks that the shipyard contains at least one stage, that the stage names are unique
+// and that all strategies are known
+func (s *Shipyard) Validate() error {
+	problems := []string{}
+	if len(s.Stages) == 0 {
+		problems = append(problems, "no stages defined")
+	}
+	names := map[string]bool{}
+	for i, stage := range s.Stages {
+		if stage.Name == "" {
+			problems = append(problems, fmt.Sprintf("stage %d has no name", i+1))
+		} else if names[stage.Name] {
+			problems = append(problems, fmt.Sprintf("stage %s is defined more than once", stage.Name))
+		}
+		names[stage.Name] = true
+
+		if !contains(knownDeploymentStrategies, stage.DeploymentStrategy) {
+			problems = append(problems, fmt.Sprintf("stage %s has unknown deployment strategy '%s'", stage.Name, stage.DeploymentStrategy))
+		}
+		if stage.TestStrategy != "" && !contains(knownTestStrategies, stage.TestStrategy) {
+			problems = append(problems, fmt.Sprintf("stage %s has unknown test strategy '%s'", stage.Name, stage.TestStrategy))
+		}
+		if stage.RemediationStrategy != "" && !contains(knownRemediationStrategies, stage.RemediationStrategy) {
+			problems = append(problems, fmt.Sprintf("stage %s has unknown remediation strategy '%s'", stage.Name, stage.RemediationStrategy))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid shipyard: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// StageByName returns the stage with the provided name or nil if it does not exist
+func (s *Shipyard) StageByName(name string) *ShipyardStage {
+	i := s.indexOf(name)
+	if i < 0 {
+		return nil
+	}
+	return &s.Stages[i]
+}
+
+// FirstStage returns the first stage or nil if the shipyard has no stages
+func (s *Shipyard) FirstStage() *ShipyardStage {
+	if len(s.Stages) == 0 {
+		return nil
+	}
+	return &s.Stages[0]
+}
+
+// NextStage returns the stage following the stage with the provided name. It returns nil
+// if the stage is the last one and an error if the stage does not exist.
+func (s *Shipyard) NextStage(name string) (*ShipyardStage, error) {
+	i := s.indexOf(name)
+	if i < 0 {
+		return nil, fmt.Errorf("Stage %s is not defined in the shipyard", name)
+	}
+	if i == len(s.Stages)-1 {
+		return nil, nil
+	}
+	return &s.Stages[i+1], nil
+}
+
+// PreviousStage returns the stage preceding the stage with the provided name. It returns nil
+// if the stage is the first one and an error if the stage does not exist.
+func (s *Shipyard) PreviousStage(name string) (*ShipyardStage, error) {
+	i := s.indexOf(name)
+	if i < 0 {
+		return nil, fmt.Errorf("Stage %s is not defined in the shipyard", name)
+	}
+	if i == 0 {
+		return nil, nil
+	}
+	return &s.Stages[i-1], nil
+}
+
+func (s *Shipyard) indexOf(name string) int {
+	for i, stage := range s.Stages {
+		if stage.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
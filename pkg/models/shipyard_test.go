@@ -0,0 +1,117 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShipyardValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		stages  []ShipyardStage
+		wantErr string
+	}{
+		{
+			name: "valid",
+			stages: []ShipyardStage{
+				{Name: "dev", DeploymentStrategy: DeploymentStrategyDirect, TestStrategy: TestStrategyFunctional},
+				{Name: "prod", DeploymentStrategy: DeploymentStrategyBlueGreenService, RemediationStrategy: RemediationStrategyAutomated},
+			},
+		},
+		{name: "no stages", wantErr: "no stages defined"},
+		{
+			name:    "missing name",
+			stages:  []ShipyardStage{{DeploymentStrategy: DeploymentStrategyDirect}},
+			wantErr: "stage 1 has no name",
+		},
+		{
+			name:    "duplicate name",
+			stages:  []ShipyardStage{{Name: "dev", DeploymentStrategy: DeploymentStrategyDirect}, {Name: "dev", DeploymentStrategy: DeploymentStrategyDirect}},
+			wantErr: "stage dev is defined more than once",
+		},
+		{
+			name:    "missing deployment strategy",
+			stages:  []ShipyardStage{{Name: "dev"}},
+			wantErr: "stage dev has unknown deployment strategy ''",
+		},
+		{
+			name:    "unknown test strategy",
+			stages:  []ShipyardStage{{Name: "dev", DeploymentStrategy: DeploymentStrategyDirect, TestStrategy: "manual"}},
+			wantErr: "stage dev has unknown test strategy 'manual'",
+		},
+		{
+			name:    "unknown remediation strategy",
+			stages:  []ShipyardStage{{Name: "dev", DeploymentStrategy: DeploymentStrategyDirect, RemediationStrategy: "manual"}},
+			wantErr: "stage dev has unknown remediation strategy 'manual'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Shipyard{Stages: tt.stages}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShipyardStageOrder(t *testing.T) {
+	shipyard := &Shipyard{Stages: []ShipyardStage{{Name: "dev"}, {Name: "staging"}, {Name: "prod"}}}
+
+	tests := []struct {
+		stage        string
+		wantNext     string
+		wantPrevious string
+	}{
+		{stage: "dev", wantNext: "staging"},
+		{stage: "staging", wantNext: "prod", wantPrevious: "dev"},
+		{stage: "prod", wantPrevious: "staging"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.stage, func(t *testing.T) {
+			if stage := shipyard.StageByName(tt.stage); stage == nil || stage.Name != tt.stage {
+				t.Errorf("got stage %v, want %s", stage, tt.stage)
+			}
+			next, err := shipyard.NextStage(tt.stage)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := stageName(next); got != tt.wantNext {
+				t.Errorf("got next stage %q, want %q", got, tt.wantNext)
+			}
+			previous, err := shipyard.PreviousStage(tt.stage)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := stageName(previous); got != tt.wantPrevious {
+				t.Errorf("got previous stage %q, want %q", got, tt.wantPrevious)
+			}
+		})
+	}
+
+	if stage := shipyard.StageByName("qa"); stage != nil {
+		t.Errorf("got stage %v for an unknown name, want nil", stage)
+	}
+	if _, err := shipyard.NextStage("qa"); err == nil {
+		t.Error("got no error for the next stage of an unknown stage, want an error")
+	}
+	if _, err := shipyard.PreviousStage("qa"); err == nil {
+		t.Error("got no error for the previous stage of an unknown stage, want an error")
+	}
+	if stage := (&Shipyard{}).FirstStage(); stage != nil {
+		t.Errorf("got first stage %v of an empty shipyard, want nil", stage)
+	}
+}
+
+func stageName(stage *ShipyardStage) string {
+	if stage == nil {
+		return ""
+	}
+	return stage.Name
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Supported versions of the shipyard format
+const (
+	// ShipyardAPIVersionV1Alpha1 is the original shipyard format. Shipyards without apiVersion have this version.
+	ShipyardAPIVersionV1Alpha1 = "keptn.sh/v1alpha1"
+	// LatestShipyardAPIVersion is the newest version of the shipyard format
+	LatestShipyardAPIVersion = ShipyardAPIVersionV1Alpha1
+)
+
+// ShipyardV1Alpha1 is a shipyard in version keptn.sh/v1alpha1
+type ShipyardV1Alpha1 struct {
+	APIVersion string          `yaml:"apiVersion,omitempty"`
+	Stages     []ShipyardStage `yaml:"stages"`
+}
+
+// shipyardVersion parses and serializes a version of the shipyard format
+type shipyardVersion struct {
+	parse   func(data []byte) (*Shipyard, error)
+	marshal func(shipyard *Shipyard) ([]byte, error)
+}
+
+var shipyardVersions = map[string]shipyardVersion{
+	ShipyardAPIVersionV1Alpha1: {parse: parseShipyardV1Alpha1, marshal: marshalShipyardV1Alpha1},
+}
+
+// GetShipyardAPIVersion returns the version of a shipyard document
+func GetShipyardAPIVersion(data []byte) (string, error) {
+	header := struct {
+		APIVersion string `yaml:"apiVersion"`
+	}{}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return "", fmt.Errorf("Error when parsing shipyard: %s", err.Error())
+	}
+	if header.APIVersion == "" {
+		return ShipyardAPIVersionV1Alpha1, nil
+	}
+	if _, ok := shipyardVersions[header.APIVersion]; !ok {
+		return "", fmt.Errorf("Unsupported shipyard apiVersion '%s'", header.APIVersion)
+	}
+	return header.APIVersion, nil
+}
+
+// ParseShipyard parses a shipyard of any supported version and converts it to the current model
+func ParseShipyard(data []byte) (*Shipyard, error) {
+	apiVersion, err := GetShipyardAPIVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	return shipyardVersions[apiVersion].parse(data)
+}
+
+// MarshalShipyard serializes a shipyard in the provided version of the shipyard format
+func MarshalShipyard(shipyard *Shipyard, apiVersion string) ([]byte, error) {
+	version, ok := shipyardVersions[apiVersion]
+	if !ok {
+		return nil, fmt.Errorf("Unsupported shipyard apiVersion '%s'", apiVersion)
+	}
+	return version.marshal(shipyard)
+}
+
+func parseShipyardV1Alpha1(data []byte) (*Shipyard, error) {
+	var versioned ShipyardV1Alpha1
+	if err := yaml.Unmarshal(data, &versioned); err != nil {
+		return nil, fmt.Errorf("Error when parsing shipyard: %s", err.Error())
+	}
+	return &Shipyard{Stages: versioned.Stages}, nil
+}
+
+func marshalShipyardV1Alpha1(shipyard *Shipyard) ([]byte, error) {
+	// the apiVersion is omitted, so older consumers can still read the shipyard
+	return yaml.Marshal(&ShipyardV1Alpha1{Stages: shipyard.Stages})
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShipyardRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "without apiVersion",
+			data: `stages:
+- name: dev
+  deployment_strategy: direct
+  test_strategy: functional
+- name: prod
+  deployment_strategy: blue_green_service
+  remediation_strategy: automated
+`,
+		},
+		{
+			name: "with apiVersion",
+			data: `apiVersion: keptn.sh/v1alpha1
+stages:
+- name: dev
+  deployment_strategy: direct
+  test_strategy: functional
+- name: prod
+  deployment_strategy: blue_green_service
+  remediation_strategy: automated
+`,
+		},
+		{
+			// shipyards which do not pass Validate can still be migrated
+			name: "without deployment strategy",
+			data: `stages:
+- name: dev
+  test_strategy: functional
+- name: prod
+  remediation_strategy: automated
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shipyard, err := ParseShipyard([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(shipyard.Stages) != 2 || shipyard.Stages[0].TestStrategy != TestStrategyFunctional || shipyard.Stages[1].RemediationStrategy != RemediationStrategyAutomated {
+				t.Fatalf("got stages %+v, want dev and prod with their strategies", shipyard.Stages)
+			}
+
+			data, err := MarshalShipyard(shipyard, LatestShipyardAPIVersion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			apiVersion, err := GetShipyardAPIVersion(data)
+			if err != nil || apiVersion != LatestShipyardAPIVersion {
+				t.Errorf("got apiVersion %q and error %v, want %s", apiVersion, err, LatestShipyardAPIVersion)
+			}
+			parsed, err := ParseShipyard(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(parsed, shipyard) {
+				t.Errorf("got shipyard %+v after the round trip, want %+v", parsed, shipyard)
+			}
+		})
+	}
+}
+
+func TestShipyardVersionErrors(t *testing.T) {
+	if _, err := ParseShipyard([]byte("apiVersion: keptn.sh/v9\nstages: []\n")); err == nil {
+		t.Error("got no error for an unsupported apiVersion, want an error")
+	}
+	if _, err := ParseShipyard([]byte("stages: [")); err == nil {
+		t.Error("got no error for invalid YAML, want an error")
+	}
+	if _, err := MarshalShipyard(&Shipyard{}, "keptn.sh/v9"); err == nil {
+		t.Error("got no error for marshaling an unsupported apiVersion, want an error")
+	}
+}
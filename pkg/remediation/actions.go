@@ -0,0 +1,140 @@
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+)
+
+// Names of the built-in actions
+const (
+	ActionScaling       = "scaling"
+	ActionRestart       = "restart"
+	ActionFeatureToggle = "featuretoggle"
+)
+
+// NewDefaultRegistry returns a Registry with handlers for the built-in actions scaling and restart. If toggles
+// is not nil, a handler for featuretoggle using this backend is registered as well.
+func NewDefaultRegistry(useInClusterConfig bool, toggles FeatureToggleBackend) *Registry {
+	registry := NewRegistry()
+	registry.Register(ActionScaling, &ScalingActionHandler{UseInClusterConfig: useInClusterConfig})
+	registry.Register(ActionRestart, &RestartActionHandler{UseInClusterConfig: useInClusterConfig})
+	if toggles != nil {
+		registry.Register(ActionFeatureToggle, &FeatureToggleActionHandler{Backend: toggles})
+	}
+	return registry
+}
+
+// ScalingActionHandler scales the deployment of the service. The value is either the number of replicas,
+// e.g. 3, or a change of the current number of replicas, e.g. +1 or -1. A dry run does not access the cluster.
+type ScalingActionHandler struct {
+	UseInClusterConfig bool
+}
+
+// Execute scales the deployment of the target
+func (h *ScalingActionHandler) Execute(ctx context.Context, action *models.RemediationAction, target Target, dryRun bool) (string, error) {
+	value := strings.TrimSpace(action.Value)
+	replicas, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("Invalid value '%s' of scaling action: %s", action.Value, err.Error())
+	}
+	deployment := target.GetDeployment()
+	namespace := target.GetNamespace()
+
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		if dryRun {
+			// the current number of replicas is not read in a dry run, which must not access the cluster
+			return fmt.Sprintf("Would change the replicas of deployment %s in namespace %s by %s", deployment, namespace, value), nil
+		}
+		current, err := utils.GetDeploymentReplicasWithContext(ctx, h.UseInClusterConfig, deployment, namespace)
+		if err != nil {
+			return "", fmt.Errorf("Error when reading replicas of deployment %s: %s", deployment, err.Error())
+		}
+		replicas += int64(current)
+	}
+	if replicas < 0 {
+		return "", fmt.Errorf("Cannot scale deployment %s to %d replicas", deployment, replicas)
+	}
+
+	if dryRun {
+		return fmt.Sprintf("Would scale deployment %s in namespace %s to %d replicas", deployment, namespace, replicas), nil
+	}
+	if err := utils.ScaleDeploymentWithContext(ctx, h.UseInClusterConfig, deployment, namespace, int32(replicas)); err != nil {
+		return "", fmt.Errorf("Error when scaling deployment %s: %s", deployment, err.Error())
+	}
+	return fmt.Sprintf("Scaled deployment %s in namespace %s to %d replicas", deployment, namespace, replicas), nil
+}
+
+// RestartActionHandler restarts the pods of the service. The value is the label selector of the pods.
+// If it is empty, app=<deployment> is used.
+type RestartActionHandler struct {
+	UseInClusterConfig bool
+}
+
+// Execute restarts the pods of the target
+func (h *RestartActionHandler) Execute(ctx context.Context, action *models.RemediationAction, target Target, dryRun bool) (string, error) {
+	selector := strings.TrimSpace(action.Value)
+	if selector == "" {
+		selector = "app=" + target.GetDeployment()
+	}
+	namespace := target.GetNamespace()
+
+	if dryRun {
+		return fmt.Sprintf("Would restart pods with selector %s in namespace %s", selector, namespace), nil
+	}
+	if err := utils.RestartPodsWithSelectorWithContext(ctx, h.UseInClusterConfig, namespace, selector); err != nil {
+		return "", fmt.Errorf("Error when restarting pods with selector %s: %s", selector, err.Error())
+	}
+	return fmt.Sprintf("Restarted pods with selector %s in namespace %s", selector, namespace), nil
+}
+
+// FeatureToggleBackend switches feature toggles, e.g. in a feature flag service
+type FeatureToggleBackend interface {
+	// SetFeatureToggle enables or disables the feature toggle of the target
+	SetFeatureToggle(ctx context.Context, target Target, toggle string, enabled bool) error
+}
+
+// FeatureToggleActionHandler switches a feature toggle using a FeatureToggleBackend.
+// The value has the form <toggle>:<on|off>, e.g. EnablePromotion:off.
+type FeatureToggleActionHandler struct {
+	Backend FeatureToggleBackend
+}
+
+// Execute switches the feature toggle of the target
+func (h *FeatureToggleActionHandler) Execute(ctx context.Context, action *models.RemediationAction, target Target, dryRun bool) (string, error) {
+	toggle, enabled, err := parseFeatureToggle(action.Value)
+	if err != nil {
+		return "", err
+	}
+	state := "off"
+	if enabled {
+		state = "on"
+	}
+
+	if dryRun {
+		return fmt.Sprintf("Would switch feature toggle %s %s", toggle, state), nil
+	}
+	if err := h.Backend.SetFeatureToggle(ctx, target, toggle, enabled); err != nil {
+		return "", fmt.Errorf("Error when switching feature toggle %s: %s", toggle, err.Error())
+	}
+	return fmt.Sprintf("Switched feature toggle %s %s", toggle, state), nil
+}
+
+func parseFeatureToggle(value string) (string, bool, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return "", false, fmt.Errorf("Invalid value '%s' of featuretoggle action, expected <toggle>:<on|off>", value)
+	}
+	toggle := strings.TrimSpace(parts[0])
+	switch strings.ToLower(strings.TrimSpace(parts[1])) {
+	case "on", "true", "enabled":
+		return toggle, true, nil
+	case "off", "false", "disabled":
+		return toggle, false, nil
+	}
+	return "", false, fmt.Errorf("Invalid value '%s' of featuretoggle action, expected <toggle>:<on|off>", value)
+}
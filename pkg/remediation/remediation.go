@@ -0,0 +1,228 @@
+// Package remediation executes the remediation actions defined for a problem.
+//
+//	registry := remediation.NewDefaultRegistry(true, toggles)
+//	executor := remediation.NewExecutor(registry)
+//	report, err := executor.Execute(ctx, remediations, problem, remediation.Target{Project: project, Stage: stage, Service: service})
+package remediation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/events"
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+// ErrNoRemediation is returned if no remediation is defined for a problem
+var ErrNoRemediation = errors.New("No remediation defined for problem")
+
+// DefaultActionTimeout is the time after which an action is aborted if the Executor defines no timeout
+const DefaultActionTimeout = 5 * time.Minute
+
+// Status of an executed action
+const (
+	// StatusSucceeded indicates that the action has been executed, or would have been executed in a dry run
+	StatusSucceeded = "succeeded"
+	// StatusFailed indicates that the action failed or timed out
+	StatusFailed = "failed"
+	// StatusSkipped indicates that the action has not been executed because a previous action failed
+	StatusSkipped = "skipped"
+)
+
+// Target is the service a remediation is executed for
+type Target struct {
+	Project string
+	Stage   string
+	Service string
+	// Namespace is the namespace of the service. If empty, <project>-<stage> is used.
+	Namespace string
+	// Deployment is the name of the deployment of the service. If empty, the service name is used.
+	Deployment string
+}
+
+// GetNamespace returns the namespace of the service
+func (t Target) GetNamespace() string {
+	if t.Namespace != "" {
+		return t.Namespace
+	}
+	return t.Project + "-" + t.Stage
+}
+
+// GetDeployment returns the name of the deployment of the service
+func (t Target) GetDeployment() string {
+	if t.Deployment != "" {
+		return t.Deployment
+	}
+	return t.Service
+}
+
+// ActionHandler executes remediation actions of one kind, e.g. scaling
+type ActionHandler interface {
+	// Execute executes the action for the target and returns a description of what has been done. In a dry run,
+	// the action is only validated and the description states what would have been done.
+	Execute(ctx context.Context, action *models.RemediationAction, target Target, dryRun bool) (string, error)
+}
+
+// Registry contains the action handlers by the name of the action they execute
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string]ActionHandler
+}
+
+// NewRegistry returns a new Registry without action handlers
+func NewRegistry() *Registry {
+	return &Registry{
+		handlers: map[string]ActionHandler{},
+	}
+}
+
+// Register registers the handler for the action. Action names are case-insensitive.
+func (r *Registry) Register(action string, handler ActionHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[strings.ToLower(action)] = handler
+}
+
+// Get returns the handler registered for the action
+func (r *Registry) Get(action string) (ActionHandler, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handler, ok := r.handlers[strings.ToLower(action)]
+	if !ok {
+		return nil, fmt.Errorf("No handler registered for action %s", action)
+	}
+	return handler, nil
+}
+
+// Report describes the execution of a remediation
+type Report struct {
+	ProblemTitle string `json:"problemTitle"`
+	// Remediation is the name of the executed remediation
+	Remediation string          `json:"remediation"`
+	DryRun      bool            `json:"dryRun"`
+	Actions     []*ActionResult `json:"actions"`
+	// Succeeded is set if all actions succeeded
+	Succeeded bool `json:"succeeded"`
+}
+
+// ActionResult describes the execution of a remediation action
+type ActionResult struct {
+	Action string `json:"action"`
+	Value  string `json:"value"`
+	// Status is succeeded, failed or skipped
+	Status string `json:"status"`
+	// Message describes what has been done or why the action failed
+	Message  string        `json:"message,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Executor executes the remediation defined for a problem
+type Executor struct {
+	Registry *Registry
+	// DryRun only validates the actions instead of executing them
+	DryRun bool
+	// ActionTimeout is the time after which an action is aborted. If 0, DefaultActionTimeout is used.
+	ActionTimeout time.Duration
+}
+
+// NewExecutor returns a new Executor using the action handlers of the registry
+func NewExecutor(registry *Registry) *Executor {
+	return &Executor{
+		Registry: registry,
+	}
+}
+
+// FindRemediation returns the remediation whose name matches the problem title, ignoring case and surrounding spaces
+func FindRemediation(remediations *models.Remediations, problemTitle string) *models.Remediation {
+	if remediations == nil {
+		return nil
+	}
+	for _, remediation := range remediations.Remediations {
+		if strings.EqualFold(strings.TrimSpace(remediation.Name), strings.TrimSpace(problemTitle)) {
+			return remediation
+		}
+	}
+	return nil
+}
+
+// Execute runs the actions of the remediation matching the title of the problem in order. If an action fails,
+// the remaining actions are skipped, except in a dry run, in which all actions are validated. Failed actions are
+// reported in the Report. If no remediation is defined for the problem, an error wrapping ErrNoRemediation is returned.
+func (e *Executor) Execute(ctx context.Context, remediations *models.Remediations, problem *events.ProblemEventData, target Target) (*Report, error) {
+	if problem == nil {
+		return nil, errors.New("No problem provided for the remediation")
+	}
+	remediation := FindRemediation(remediations, problem.ProblemTitle)
+	if remediation == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoRemediation, problem.ProblemTitle)
+	}
+
+	report := &Report{
+		ProblemTitle: problem.ProblemTitle,
+		Remediation:  remediation.Name,
+		DryRun:       e.DryRun,
+		Actions:      []*ActionResult{},
+		Succeeded:    true,
+	}
+	for _, action := range remediation.Actions {
+		result := &ActionResult{
+			Action: action.Action,
+			Value:  action.Value,
+		}
+		report.Actions = append(report.Actions, result)
+		if !report.Succeeded && !e.DryRun {
+			result.Status = StatusSkipped
+			continue
+		}
+
+		start := time.Now()
+		message, err := e.executeAction(ctx, action, target)
+		result.Duration = time.Since(start)
+		if err != nil {
+			result.Status = StatusFailed
+			result.Message = err.Error()
+			report.Succeeded = false
+			continue
+		}
+		result.Status = StatusSucceeded
+		result.Message = message
+	}
+	return report, nil
+}
+
+// executeAction executes the action with its handler and aborts it after the action timeout. The built-in handlers
+// cancel their requests to the cluster then; handlers which do not observe the cancellation of the context keep
+// running in the background.
+func (e *Executor) executeAction(ctx context.Context, action *models.RemediationAction, target Target) (string, error) {
+	handler, err := e.Registry.Get(action.Action)
+	if err != nil {
+		return "", err
+	}
+
+	timeout := e.ActionTimeout
+	if timeout <= 0 {
+		timeout = DefaultActionTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		message string
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		message, err := handler.Execute(ctx, action, target, e.DryRun)
+		done <- result{message: message, err: err}
+	}()
+	select {
+	case r := <-done:
+		return r.message, r.err
+	case <-ctx.Done():
+		return "", fmt.Errorf("Action %s aborted: %s", action.Action, ctx.Err().Error())
+	}
+}
@@ -0,0 +1,138 @@
+package remediation_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/events"
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/remediation"
+)
+
+type toggleBackend struct {
+	toggles map[string]bool
+}
+
+func (b *toggleBackend) SetFeatureToggle(ctx context.Context, target remediation.Target, toggle string, enabled bool) error {
+	b.toggles[toggle] = enabled
+	return nil
+}
+
+// blockingHandler blocks until the context of the action is done
+type blockingHandler struct{}
+
+func (blockingHandler) Execute(ctx context.Context, action *models.RemediationAction, target remediation.Target, dryRun bool) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestExecute(t *testing.T) {
+	remediations := &models.Remediations{Remediations: []*models.Remediation{
+		{Name: "High CPU", Actions: []*models.RemediationAction{
+			{Action: "scaling", Value: "+1"},
+			{Action: "featuretoggle", Value: "EnablePromotion:off"},
+			{Action: "restart"},
+		}},
+		{Name: "Hanging", Actions: []*models.RemediationAction{
+			{Action: "blocking"},
+			{Action: "featuretoggle", Value: "EnablePromotion:off"},
+		}},
+		{Name: "Invalid", Actions: []*models.RemediationAction{
+			{Action: "scaling", Value: "many"},
+			{Action: "unknown"},
+			{Action: "featuretoggle", Value: "EnablePromotion"},
+		}},
+	}}
+	target := remediation.Target{Project: "sockshop", Stage: "production", Service: "carts"}
+
+	tests := []struct {
+		name          string
+		problemTitle  string
+		dryRun        bool
+		wantSucceeded bool
+		wantStatus    []string
+		wantToggles   int
+	}{
+		{
+			// the dry run must not read the current replicas from the cluster, which is not available here
+			name:          "dry run of relative scaling",
+			problemTitle:  " high cpu ",
+			dryRun:        true,
+			wantSucceeded: true,
+			wantStatus:    []string{remediation.StatusSucceeded, remediation.StatusSucceeded, remediation.StatusSucceeded},
+		},
+		{
+			name:         "timeout skips the remaining actions",
+			problemTitle: "Hanging",
+			wantStatus:   []string{remediation.StatusFailed, remediation.StatusSkipped},
+		},
+		{
+			name:         "dry run validates all actions",
+			problemTitle: "Invalid",
+			dryRun:       true,
+			wantStatus:   []string{remediation.StatusFailed, remediation.StatusFailed, remediation.StatusFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &toggleBackend{toggles: map[string]bool{}}
+			registry := remediation.NewDefaultRegistry(false, backend)
+			registry.Register("blocking", blockingHandler{})
+			executor := remediation.NewExecutor(registry)
+			executor.DryRun = tt.dryRun
+			executor.ActionTimeout = 50 * time.Millisecond
+
+			report, err := executor.Execute(context.Background(), remediations, &events.ProblemEventData{ProblemTitle: tt.problemTitle}, target)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Succeeded != tt.wantSucceeded {
+				t.Errorf("got succeeded %t, want %t", report.Succeeded, tt.wantSucceeded)
+			}
+			status := []string{}
+			for _, action := range report.Actions {
+				status = append(status, action.Status)
+			}
+			if strings.Join(status, ",") != strings.Join(tt.wantStatus, ",") {
+				t.Errorf("got status %v, want %v", status, tt.wantStatus)
+			}
+			if len(backend.toggles) != tt.wantToggles {
+				t.Errorf("got toggles %v, want %d", backend.toggles, tt.wantToggles)
+			}
+		})
+	}
+}
+
+func TestExecuteSwitchesFeatureToggle(t *testing.T) {
+	backend := &toggleBackend{toggles: map[string]bool{}}
+	executor := remediation.NewExecutor(remediation.NewDefaultRegistry(false, backend))
+	remediations := &models.Remediations{Remediations: []*models.Remediation{
+		{Name: "Failure rate increase", Actions: []*models.RemediationAction{{Action: "FeatureToggle", Value: "EnablePromotion:off"}}},
+	}}
+
+	report, err := executor.Execute(context.Background(), remediations, &events.ProblemEventData{ProblemTitle: "Failure rate increase"}, remediation.Target{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Succeeded {
+		t.Errorf("got report %+v, want the remediation to succeed", report)
+	}
+	if enabled, ok := backend.toggles["EnablePromotion"]; !ok || enabled {
+		t.Errorf("got toggles %v, want EnablePromotion to be switched off", backend.toggles)
+	}
+}
+
+func TestExecuteWithoutRemediation(t *testing.T) {
+	executor := remediation.NewExecutor(remediation.NewRegistry())
+	remediations := &models.Remediations{Remediations: []*models.Remediation{{Name: "High CPU"}}}
+
+	if _, err := executor.Execute(context.Background(), remediations, &events.ProblemEventData{ProblemTitle: "Response time degradation"}, remediation.Target{}); !errors.Is(err, remediation.ErrNoRemediation) {
+		t.Errorf("got error %v, want ErrNoRemediation", err)
+	}
+	if _, err := executor.Execute(context.Background(), remediations, nil, remediation.Target{}); err == nil {
+		t.Error("got no error for a missing problem, want an error")
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+// APIError is returned by the config service handlers if a request has been
+// answered with a non-successful status code
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Code is the error code contained in the models.Error of the response
+	Code int64
+	// Message is the error message contained in the models.Error of the response
+	Message string
+	// Method is the HTTP method of the failed request
+	Method string
+	// URI is the URI of the failed request
+	URI string
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%s %s failed with status code %d (error code %d): %s", e.Method, e.URI, e.StatusCode, e.Code, msg)
+}
+
+// IsNotFound returns true if the requested entity does not exist
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsConflict returns true if the request conflicts with the current state of the entity,
+// e.g. because the expected version of a resource does not match the stored one
+func (e *APIError) IsConflict() bool {
+	return e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusPreconditionFailed
+}
+
+// IsUnauthorized returns true if the request has not been authorized
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// IsNotFoundError returns true if err is an APIError indicating that the requested entity does not exist
+func IsNotFoundError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsNotFound()
+}
+
+// IsConflictError returns true if err is an APIError indicating a conflict
+func IsConflictError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsConflict()
+}
+
+// IsUnauthorizedError returns true if err is an APIError indicating that the request has not been authorized
+func IsUnauthorizedError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsUnauthorized()
+}
+
+// newAPIError builds an APIError from the response body of a failed request.
+// If the body does not contain a models.Error, it is used as message.
+func newAPIError(method string, uri string, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		URI:        uri,
+	}
+	var respErr models.Error
+	if err := json.Unmarshal(body, &respErr); err == nil && respErr.Message != nil {
+		apiErr.Code = respErr.Code
+		apiErr.Message = *respErr.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		body             string
+		wantCode         int64
+		wantMessage      string
+		wantNotFound     bool
+		wantConflict     bool
+		wantUnauthorized bool
+	}{
+		{
+			name:         "JSON error body",
+			status:       http.StatusNotFound,
+			body:         `{"code":404,"message":"Project sockshop not found"}`,
+			wantCode:     404,
+			wantMessage:  "Project sockshop not found",
+			wantNotFound: true,
+		},
+		{
+			name:         "JSON body without message",
+			status:       http.StatusConflict,
+			body:         `{"code":409}`,
+			wantMessage:  `{"code":409}`,
+			wantConflict: true,
+		},
+		{
+			name:         "non-JSON body",
+			status:       http.StatusPreconditionFailed,
+			body:         "version mismatch\n",
+			wantMessage:  "version mismatch",
+			wantConflict: true,
+		},
+		{
+			name:             "empty body",
+			status:           http.StatusUnauthorized,
+			wantUnauthorized: true,
+		},
+		{
+			name:             "forbidden",
+			status:           http.StatusForbidden,
+			body:             "<html>Forbidden</html>",
+			wantMessage:      "<html>Forbidden</html>",
+			wantUnauthorized: true,
+		},
+		{
+			name:        "server error",
+			status:      http.StatusInternalServerError,
+			body:        `{"code":500,"message":"Internal error"}`,
+			wantCode:    500,
+			wantMessage: "Internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(tt.status)
+			rec.WriteString(tt.body)
+
+			resp, err := checkResponse("GET", "http://configuration-service/v1/project/sockshop", rec.Result(), nil)
+			if resp != nil {
+				t.Errorf("got response with status %d, want none", resp.StatusCode)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got error %v, want an *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Code != tt.wantCode || apiErr.Message != tt.wantMessage {
+				t.Errorf("got status %d, code %d and message %q, want %d, %d and %q", apiErr.StatusCode, apiErr.Code, apiErr.Message, tt.status, tt.wantCode, tt.wantMessage)
+			}
+			if apiErr.Method != "GET" || apiErr.URI != "http://configuration-service/v1/project/sockshop" {
+				t.Errorf("got request %s %s, want the failed request", apiErr.Method, apiErr.URI)
+			}
+
+			// the predicates have to see through wrapped errors
+			wrapped := fmt.Errorf("Error when getting project: %w", err)
+			if IsNotFoundError(wrapped) != tt.wantNotFound {
+				t.Errorf("got IsNotFoundError %t, want %t", !tt.wantNotFound, tt.wantNotFound)
+			}
+			if IsConflictError(wrapped) != tt.wantConflict {
+				t.Errorf("got IsConflictError %t, want %t", !tt.wantConflict, tt.wantConflict)
+			}
+			if IsUnauthorizedError(wrapped) != tt.wantUnauthorized {
+				t.Errorf("got IsUnauthorizedError %t, want %t", !tt.wantUnauthorized, tt.wantUnauthorized)
+			}
+		})
+	}
+}
+
+func TestCheckResponsePassesSuccess(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(http.StatusNoContent)
+	resp, err := checkResponse("DELETE", "http://configuration-service/v1/project/sockshop", rec.Result(), nil)
+	if err != nil || resp == nil || resp.StatusCode != http.StatusNoContent {
+		t.Errorf("got response %v and error %v, want the successful response", resp, err)
+	}
+
+	sendErr := errors.New("connection refused")
+	if _, err := checkResponse("GET", "http://configuration-service/v1/project", nil, sendErr); err != sendErr {
+		t.Errorf("got error %v, want the error of the request", err)
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := &APIError{StatusCode: http.StatusNotFound, Method: "GET", URI: "http://configuration-service/v1/project/sockshop"}
+	want := "GET http://configuration-service/v1/project/sockshop failed with status code 404 (error code 0): Not Found"
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+	if IsNotFoundError(errors.New("404")) {
+		t.Error("got IsNotFoundError for an error which is no APIError")
+	}
+}
@@ -0,0 +1,256 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authenticator adds credentials to the requests sent to the configuration service
+type Authenticator interface {
+	// Authenticate adds the credentials to the request
+	Authenticate(req *http.Request) error
+	// Refresh renews the credentials after a request has been rejected with 401. It returns false if
+	// the credentials cannot be renewed, in which case the request is not sent again.
+	Refresh(ctx context.Context) (bool, error)
+}
+
+// StaticTokenAuthenticator sets a static token in a header, e.g. the keptn API token in x-token
+type StaticTokenAuthenticator struct {
+	Header string
+	Token  string
+}
+
+// NewStaticTokenAuthenticator returns a new StaticTokenAuthenticator
+func NewStaticTokenAuthenticator(header string, token string) *StaticTokenAuthenticator {
+	return &StaticTokenAuthenticator{
+		Header: header,
+		Token:  token,
+	}
+}
+
+// Authenticate sets the token in the header of the request
+func (a *StaticTokenAuthenticator) Authenticate(req *http.Request) error {
+	if a.Header != "" && a.Token != "" {
+		req.Header.Set(a.Header, a.Token)
+	}
+	return nil
+}
+
+// Refresh returns false, as a static token cannot be renewed
+func (a *StaticTokenAuthenticator) Refresh(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+// DefaultTokenReloadInterval is the interval in which a TokenFileAuthenticator reads its file again
+const DefaultTokenReloadInterval = time.Minute
+
+// TokenFileAuthenticator sends a bearer token read from a file, e.g. a projected Kubernetes service account token.
+// The file is read again periodically and whenever a request is rejected, so rotated tokens are picked up.
+type TokenFileAuthenticator struct {
+	// Path is the path of the token file
+	Path string
+	// ReloadInterval is the interval in which the file is read again. If 0, DefaultTokenReloadInterval is used.
+	ReloadInterval time.Duration
+
+	mu       sync.Mutex
+	token    string
+	loadedAt time.Time
+}
+
+// NewTokenFileAuthenticator returns a new TokenFileAuthenticator reading the token from path
+func NewTokenFileAuthenticator(path string) *TokenFileAuthenticator {
+	return &TokenFileAuthenticator{
+		Path: path,
+	}
+}
+
+// Authenticate sets the token as bearer token of the request
+func (a *TokenFileAuthenticator) Authenticate(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	reloadInterval := a.ReloadInterval
+	if reloadInterval <= 0 {
+		reloadInterval = DefaultTokenReloadInterval
+	}
+	if a.token == "" || time.Since(a.loadedAt) >= reloadInterval {
+		if _, err := a.load(); err != nil {
+			return err
+		}
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+// Refresh reads the token file again. It returns true if the token has changed.
+func (a *TokenFileAuthenticator) Refresh(ctx context.Context) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.load()
+}
+
+// load reads the token file and returns whether the token has changed
+func (a *TokenFileAuthenticator) load() (bool, error) {
+	data, err := ioutil.ReadFile(a.Path)
+	if err != nil {
+		return false, fmt.Errorf("Error when reading token file %s: %s", a.Path, err.Error())
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return false, fmt.Errorf("Error when reading token file %s: file is empty", a.Path)
+	}
+	changed := token != a.token
+	a.token = token
+	a.loadedAt = time.Now()
+	return changed, nil
+}
+
+// tokenExpiryDelta is the time before its expiry at which an OAuth2 token is renewed
+const tokenExpiryDelta = 10 * time.Second
+
+// OAuth2ClientCredentialsAuthenticator sends a bearer token obtained by the OAuth2 client credentials flow.
+// The token is renewed shortly before it expires and whenever a request is rejected. Concurrent requests
+// share a single token request, and requests waiting for it give up once their context is done.
+type OAuth2ClientCredentialsAuthenticator struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// HTTPClient is used for requesting tokens. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	// fetch is the token request in progress, if any. mu is not held while it is sent.
+	fetch *tokenFetch
+}
+
+// tokenFetch is a token request shared by all callers needing a new token
+type tokenFetch struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// NewOAuth2ClientCredentialsAuthenticator returns a new OAuth2ClientCredentialsAuthenticator
+func NewOAuth2ClientCredentialsAuthenticator(tokenURL string, clientID string, clientSecret string, scopes ...string) *OAuth2ClientCredentialsAuthenticator {
+	return &OAuth2ClientCredentialsAuthenticator{
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+	}
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Authenticate sets the access token as bearer token of the request. A new token is requested if necessary.
+func (a *OAuth2ClientCredentialsAuthenticator) Authenticate(req *http.Request) error {
+	token, err := a.getToken(req.Context(), false)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Refresh requests a new access token
+func (a *OAuth2ClientCredentialsAuthenticator) Refresh(ctx context.Context) (bool, error) {
+	if _, err := a.getToken(ctx, true); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getToken returns the cached token unless it expires soon or renew is set. Otherwise, it joins the
+// token request in progress or sends a new one.
+func (a *OAuth2ClientCredentialsAuthenticator) getToken(ctx context.Context, renew bool) (string, error) {
+	a.mu.Lock()
+	if !renew && a.token != "" && (a.expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(a.expiry)) {
+		token := a.token
+		a.mu.Unlock()
+		return token, nil
+	}
+	if f := a.fetch; f != nil {
+		a.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.token, f.err
+		case <-ctx.Done():
+			return "", fmt.Errorf("Error when waiting for OAuth2 token: %s", ctx.Err().Error())
+		}
+	}
+	f := &tokenFetch{done: make(chan struct{})}
+	a.fetch = f
+	a.mu.Unlock()
+
+	var expiry time.Time
+	f.token, expiry, f.err = a.requestToken(ctx)
+
+	a.mu.Lock()
+	if f.err == nil {
+		a.token, a.expiry = f.token, expiry
+	}
+	a.fetch = nil
+	a.mu.Unlock()
+	close(f.done)
+	return f.token, f.err
+}
+
+// requestToken requests a new access token and returns it together with its expiry, which is zero if unknown
+func (a *OAuth2ClientCredentialsAuthenticator) requestToken(ctx context.Context) (string, time.Time, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(a.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", a.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(a.ClientID), url.QueryEscape(a.ClientSecret))
+
+	httpClient := a.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("Error when requesting OAuth2 token: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("Error when requesting OAuth2 token: %s", err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("Error when requesting OAuth2 token: status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var token oauth2TokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", time.Time{}, fmt.Errorf("Error when parsing OAuth2 token: %s", err.Error())
+	}
+	if token.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("Error when parsing OAuth2 token: access_token is missing")
+	}
+
+	var expiry time.Time
+	if token.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return token.AccessToken, expiry, nil
+}
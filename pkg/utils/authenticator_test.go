@@ -0,0 +1,284 @@
+package utils_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+)
+
+// newTokenServer returns an OAuth2 token endpoint issuing the tokens token-1, token-2, ... which expire after expiresIn seconds
+func newTokenServer(t *testing.T, expiresIn int, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if r.Method != "POST" || r.FormValue("grant_type") != "client_credentials" || clientID != "keptn" || clientSecret != "secret" {
+			t.Errorf("got token request %s with grant type %q and client %s:%s, want a client credentials grant", r.Method, r.FormValue("grant_type"), clientID, clientSecret)
+		}
+		n := atomic.AddInt32(requests, 1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, expiresIn)
+	}))
+}
+
+func authorizationOf(t *testing.T, a utils.Authenticator) string {
+	req := httptest.NewRequest("GET", "http://configuration-service/v1/project", nil)
+	if err := a.Authenticate(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return req.Header.Get("Authorization")
+}
+
+func TestOAuth2ClientCredentialsAuthenticator(t *testing.T) {
+	tests := []struct {
+		name         string
+		expiresIn    int
+		wantTokens   []string
+		wantRequests int32
+	}{
+		{name: "cached token", expiresIn: 3600, wantTokens: []string{"token-1", "token-1"}, wantRequests: 1},
+		{name: "token without expiry", expiresIn: 0, wantTokens: []string{"token-1", "token-1"}, wantRequests: 1},
+		// tokens expiring within the expiry delta are renewed before each request
+		{name: "expiring token", expiresIn: 1, wantTokens: []string{"token-1", "token-2"}, wantRequests: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			srv := newTokenServer(t, tt.expiresIn, &requests)
+			defer srv.Close()
+			a := utils.NewOAuth2ClientCredentialsAuthenticator(srv.URL, "keptn", "secret", "configuration")
+
+			for _, want := range tt.wantTokens {
+				if got := authorizationOf(t, a); got != "Bearer "+want {
+					t.Errorf("got Authorization %q, want %q", got, "Bearer "+want)
+				}
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("got %d token requests, want %d", requests, tt.wantRequests)
+			}
+
+			if refreshed, err := a.Refresh(context.Background()); !refreshed || err != nil {
+				t.Errorf("got refreshed %t and error %v, want a new token", refreshed, err)
+			}
+			if requests != tt.wantRequests+1 {
+				t.Errorf("got %d token requests after refreshing, want %d", requests, tt.wantRequests+1)
+			}
+		})
+	}
+}
+
+func TestOAuth2ClientCredentialsAuthenticatorErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "rejected credentials", status: http.StatusUnauthorized, body: `{"error":"invalid_client"}`},
+		{name: "invalid response", status: http.StatusOK, body: `{`},
+		{name: "missing access token", status: http.StatusOK, body: `{"token_type":"bearer"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			a := utils.NewOAuth2ClientCredentialsAuthenticator(srv.URL, "keptn", "secret")
+			if err := a.Authenticate(httptest.NewRequest("GET", "http://configuration-service/v1/project", nil)); err == nil {
+				t.Error("got no error, want an error")
+			}
+		})
+	}
+}
+
+func TestOAuth2ClientCredentialsAuthenticatorSharesTokenRequest(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte(`{"access_token":"slow-token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	a := utils.NewOAuth2ClientCredentialsAuthenticator(srv.URL, "keptn", "secret")
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 5)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest("GET", "http://configuration-service/v1/project", nil)
+			if err := a.Authenticate(req); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			tokens[i] = req.Header.Get("Authorization")
+		}(i)
+	}
+
+	// a caller must not be blocked beyond its own deadline by the slow token request
+	time.Sleep(50 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := a.Authenticate(httptest.NewRequest("GET", "http://configuration-service/v1/project", nil).WithContext(ctx))
+	if err == nil || time.Since(start) > time.Second {
+		t.Errorf("got error %v after %s, want the deadline to be exceeded", err, time.Since(start))
+	}
+
+	close(release)
+	wg.Wait()
+	for _, token := range tokens {
+		if token != "Bearer slow-token" {
+			t.Errorf("got Authorization %q, want the shared token", token)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d token requests, want one shared request", requests)
+	}
+}
+
+func TestTokenFileAuthenticator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keptn-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+	writeToken := func(token string) {
+		if err := ioutil.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeToken("first")
+	a := utils.NewTokenFileAuthenticator(path)
+	a.ReloadInterval = 50 * time.Millisecond
+	if got := authorizationOf(t, a); got != "Bearer first" {
+		t.Errorf("got Authorization %q, want the token of the file", got)
+	}
+
+	writeToken("second")
+	if got := authorizationOf(t, a); got != "Bearer first" {
+		t.Errorf("got Authorization %q before the reload interval, want the cached token", got)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if got := authorizationOf(t, a); got != "Bearer second" {
+		t.Errorf("got Authorization %q after the reload interval, want the rotated token", got)
+	}
+
+	if refreshed, err := a.Refresh(context.Background()); refreshed || err != nil {
+		t.Errorf("got refreshed %t and error %v for an unchanged file, want false", refreshed, err)
+	}
+	writeToken("")
+	if _, err := a.Refresh(context.Background()); err == nil {
+		t.Error("got no error for an empty token file, want an error")
+	}
+}
+
+// newAuthServer returns a configuration service accepting only the bearer token stored in validToken
+func newAuthServer(validToken *atomic.Value, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.Body != nil {
+			io.Copy(ioutil.Discard, r.Body)
+		}
+		if r.Header.Get("Authorization") != "Bearer "+validToken.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"projectName":"sockshop","version":"1"}`))
+	}))
+}
+
+func TestRefreshAfterUnauthorized(t *testing.T) {
+	t.Run("token file rotated while in use", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "keptn-token")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "token")
+		ioutil.WriteFile(path, []byte("old"), 0600)
+
+		var requests int32
+		var validToken atomic.Value
+		validToken.Store("old")
+		srv := newAuthServer(&validToken, &requests)
+		defer srv.Close()
+		client, err := utils.NewClient(utils.WithBaseURL(srv.URL), utils.WithAuthenticator(utils.NewTokenFileAuthenticator(path)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Projects().GetProject(models.Project{ProjectName: "sockshop"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// the server only accepts the rotated token, which is read again after the cached one has been rejected
+		validToken.Store("new")
+		ioutil.WriteFile(path, []byte("new"), 0600)
+		atomic.StoreInt32(&requests, 0)
+		if _, err := client.Projects().GetProject(models.Project{ProjectName: "sockshop"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if requests != 2 {
+			t.Errorf("got %d requests, want the rejected request and one retry", requests)
+		}
+	})
+
+	t.Run("exactly one retry", func(t *testing.T) {
+		var tokenRequests, requests int32
+		tokenSrv := newTokenServer(t, 3600, &tokenRequests)
+		defer tokenSrv.Close()
+		var validToken atomic.Value
+		validToken.Store("never-issued")
+		srv := newAuthServer(&validToken, &requests)
+		defer srv.Close()
+		client, err := utils.NewClient(utils.WithBaseURL(srv.URL), utils.WithAuthenticator(utils.NewOAuth2ClientCredentialsAuthenticator(tokenSrv.URL, "keptn", "secret")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.Projects().GetProject(models.Project{ProjectName: "sockshop"}); !utils.IsUnauthorizedError(err) {
+			t.Errorf("got error %v, want unauthorized", err)
+		}
+		if requests != 2 || tokenRequests != 2 {
+			t.Errorf("got %d requests and %d token requests, want two of each", requests, tokenRequests)
+		}
+	})
+
+	t.Run("non-replayable body", func(t *testing.T) {
+		var tokenRequests, requests int32
+		tokenSrv := newTokenServer(t, 3600, &tokenRequests)
+		defer tokenSrv.Close()
+		var validToken atomic.Value
+		validToken.Store("never-issued")
+		srv := newAuthServer(&validToken, &requests)
+		defer srv.Close()
+		client, err := utils.NewClient(utils.WithBaseURL(srv.URL), utils.WithAuthenticator(utils.NewOAuth2ClientCredentialsAuthenticator(tokenSrv.URL, "keptn", "secret")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// an io.MultiReader cannot be rewound, so the upload cannot be sent again
+		content := io.MultiReader(strings.NewReader("replicas: 1"))
+		if _, err := client.Resources().WriteResourceContent(context.Background(), utils.NewProjectScope("sockshop"), "values.yaml", content); !utils.IsUnauthorizedError(err) {
+			t.Errorf("got error %v, want unauthorized", err)
+		}
+		if requests != 1 || tokenRequests != 1 {
+			t.Errorf("got %d requests and %d token requests, want one of each", requests, tokenRequests)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"context"
+	"io"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+//go:generate moq -pkg mocks -out mocks/projectAPI.go . ProjectAPI
+//go:generate moq -pkg mocks -out mocks/stageAPI.go . StageAPI
+//go:generate moq -pkg mocks -out mocks/serviceAPI.go . ServiceAPI
+//go:generate moq -pkg mocks -out mocks/resourceAPI.go . ResourceAPI
+
+// ProjectAPI provides access to the projects of the configuration service. It is implemented by ProjectHandler.
+type ProjectAPI interface {
+	CreateProject(project models.Project) error
+	CreateProjectWithContext(ctx context.Context, project models.Project) error
+	DeleteProject(project models.Project) error
+	DeleteProjectWithContext(ctx context.Context, project models.Project) error
+	GetProject(project models.Project) (*models.Project, error)
+	GetProjectWithContext(ctx context.Context, project models.Project) (*models.Project, error)
+	UpdateProject(project models.Project) error
+	UpdateProjectWithContext(ctx context.Context, project models.Project) error
+	GetAllProjects() ([]*models.Project, error)
+	GetAllProjectsWithContext(ctx context.Context) ([]*models.Project, error)
+	IterateProjects(ctx context.Context, pageSize int) ProjectIterator
+}
+
+// StageAPI provides access to the stages of the configuration service. It is implemented by StageHandler.
+type StageAPI interface {
+	CreateStage(project string, stageName string) error
+	CreateStageWithContext(ctx context.Context, project string, stageName string) error
+	GetAllStages(project string) ([]*models.Stage, error)
+	GetAllStagesWithContext(ctx context.Context, project string) ([]*models.Stage, error)
+	IterateStages(ctx context.Context, project string, pageSize int) StageIterator
+	GetStage(project string, stageName string) (*models.Stage, error)
+	GetStageWithContext(ctx context.Context, project string, stageName string) (*models.Stage, error)
+	UpdateStage(project string, stageName string, stage models.Stage) error
+	UpdateStageWithContext(ctx context.Context, project string, stageName string, stage models.Stage) error
+	DeleteStage(project string, stageName string) error
+	DeleteStageWithContext(ctx context.Context, project string, stageName string) error
+}
+
+// ServiceAPI provides access to the services of the configuration service. It is implemented by ServiceHandler.
+type ServiceAPI interface {
+	CreateService(project string, stage string, serviceName string) error
+	CreateServiceWithContext(ctx context.Context, project string, stage string, serviceName string) error
+	GetAllServices(project string, stage string) ([]*models.Service, error)
+	GetAllServicesWithContext(ctx context.Context, project string, stage string) ([]*models.Service, error)
+	IterateServices(ctx context.Context, project string, stage string, pageSize int) ServiceIterator
+	GetService(project string, stage string, serviceName string) (*models.Service, error)
+	GetServiceWithContext(ctx context.Context, project string, stage string, serviceName string) (*models.Service, error)
+	UpdateService(project string, stage string, serviceName string, service models.Service) error
+	UpdateServiceWithContext(ctx context.Context, project string, stage string, serviceName string, service models.Service) error
+	DeleteService(project string, stage string, serviceName string) error
+	DeleteServiceWithContext(ctx context.Context, project string, stage string, serviceName string) error
+}
+
+// ResourceAPI provides access to the resources of the configuration service. It is implemented by ResourceHandler.
+type ResourceAPI interface {
+	CreateProjectResources(project string, resources []*models.Resource) (string, error)
+	CreateProjectResourcesWithContext(ctx context.Context, project string, resources []*models.Resource) (string, error)
+	GetProjectResource(project string, resourceURI string) (*models.Resource, error)
+	GetProjectResourceWithContext(ctx context.Context, project string, resourceURI string) (*models.Resource, error)
+	UpdateProjectResource(project string, resource *models.Resource) (string, error)
+	UpdateProjectResourceWithContext(ctx context.Context, project string, resource *models.Resource) (string, error)
+	DeleteProjectResource(project string, resourceURI string) error
+	DeleteProjectResourceWithContext(ctx context.Context, project string, resourceURI string) error
+	UpdateProjectResources(project string, resources []*models.Resource) (string, error)
+	UpdateProjectResourcesWithContext(ctx context.Context, project string, resources []*models.Resource) (string, error)
+
+	CreateStageResources(project string, stage string, resources []*models.Resource) (string, error)
+	CreateStageResourcesWithContext(ctx context.Context, project string, stage string, resources []*models.Resource) (string, error)
+	GetStageResource(project string, stage string, resourceURI string) (*models.Resource, error)
+	GetStageResourceWithContext(ctx context.Context, project string, stage string, resourceURI string) (*models.Resource, error)
+	UpdateStageResource(project string, stage string, resource *models.Resource) (string, error)
+	UpdateStageResourceWithContext(ctx context.Context, project string, stage string, resource *models.Resource) (string, error)
+	UpdateStageResources(project string, stage string, resources []*models.Resource) (string, error)
+	UpdateStageResourcesWithContext(ctx context.Context, project string, stage string, resources []*models.Resource) (string, error)
+	DeleteStageResource(project string, stage string, resourceURI string) error
+	DeleteStageResourceWithContext(ctx context.Context, project string, stage string, resourceURI string) error
+
+	CreateServiceResources(project string, stage string, service string, resources []*models.Resource) (string, error)
+	CreateServiceResourcesWithContext(ctx context.Context, project string, stage string, service string, resources []*models.Resource) (string, error)
+	GetServiceResource(project string, stage string, service string, resourceURI string) (*models.Resource, error)
+	GetServiceResourceWithContext(ctx context.Context, project string, stage string, service string, resourceURI string) (*models.Resource, error)
+	UpdateServiceResource(project string, stage string, service string, resource *models.Resource) (string, error)
+	UpdateServiceResourceWithContext(ctx context.Context, project string, stage string, service string, resource *models.Resource) (string, error)
+	UpdateServiceResources(project string, stage string, service string, resources []*models.Resource) (string, error)
+	UpdateServiceResourcesWithContext(ctx context.Context, project string, stage string, service string, resources []*models.Resource) (string, error)
+	DeleteServiceResource(project string, stage string, service string, resourceURI string) error
+	DeleteServiceResourceWithContext(ctx context.Context, project string, stage string, service string, resourceURI string) error
+
+	GetAllStageResources(project string, stage string) ([]*models.Resource, error)
+	GetAllStageResourcesWithContext(ctx context.Context, project string, stage string) ([]*models.Resource, error)
+	IterateStageResources(ctx context.Context, project string, stage string, pageSize int) ResourceIterator
+	GetAllProjectResources(project string) ([]*models.Resource, error)
+	GetAllProjectResourcesWithContext(ctx context.Context, project string) ([]*models.Resource, error)
+	IterateProjectResources(ctx context.Context, project string, pageSize int) ResourceIterator
+	GetAllServiceResources(project string, stage string, service string) ([]*models.Resource, error)
+	GetAllServiceResourcesWithContext(ctx context.Context, project string, stage string, service string) ([]*models.Resource, error)
+	IterateServiceResources(ctx context.Context, project string, stage string, service string, pageSize int) ResourceIterator
+	GetAllResourcesWithContext(ctx context.Context, scope ResourceScope) ([]*models.Resource, error)
+	IterateResources(ctx context.Context, scope ResourceScope, pageSize int) ResourceIterator
+	GetResourceURIs(scope ResourceScope) ([]*models.VersionedResource, error)
+	GetResourceURIsWithContext(ctx context.Context, scope ResourceScope) ([]*models.VersionedResource, error)
+	IterateResourceURIs(ctx context.Context, scope ResourceScope, pageSize int) ResourceIterator
+
+	GetProjectResourceAtVersion(project string, resourceURI string, version string) (*models.Resource, error)
+	GetStageResourceAtVersion(project string, stage string, resourceURI string, version string) (*models.Resource, error)
+	GetServiceResourceAtVersion(project string, stage string, service string, resourceURI string, version string) (*models.Resource, error)
+	GetResourceAtVersionWithContext(ctx context.Context, scope ResourceScope, resourceURI string, version string) (*models.Resource, error)
+	GetResourceVersions(scope ResourceScope, resourceURI string) ([]*models.Version, error)
+	GetResourceVersionsWithContext(ctx context.Context, scope ResourceScope, resourceURI string) ([]*models.Version, error)
+	IterateResourceVersions(ctx context.Context, scope ResourceScope, resourceURI string, pageSize int) VersionIterator
+
+	UpdateProjectResourceIfVersion(project string, resource *models.Resource, expectedVersion string) (string, error)
+	UpdateStageResourceIfVersion(project string, stage string, resource *models.Resource, expectedVersion string) (string, error)
+	UpdateServiceResourceIfVersion(project string, stage string, service string, resource *models.Resource, expectedVersion string) (string, error)
+	UpdateResourceIfVersionWithContext(ctx context.Context, scope ResourceScope, resource *models.Resource, expectedVersion string) (string, error)
+	ModifyResource(scope ResourceScope, resourceURI string, modify func(old *models.Resource) (*models.Resource, error)) (string, error)
+	ModifyResourceWithContext(ctx context.Context, scope ResourceScope, resourceURI string, modify func(old *models.Resource) (*models.Resource, error)) (string, error)
+
+	GetResourceContent(ctx context.Context, scope ResourceScope, resourceURI string, version string) ([]byte, error)
+	ReadResourceContent(ctx context.Context, scope ResourceScope, resourceURI string, version string, w io.Writer) (*models.VersionedResource, error)
+	WriteResourceBytes(ctx context.Context, scope ResourceScope, resourceURI string, content []byte) (string, error)
+	WriteResourceContent(ctx context.Context, scope ResourceScope, resourceURI string, content io.Reader) (string, error)
+	SyncDirectory(ctx context.Context, dir string, scope ResourceScope, opts SyncOptions) ([]SyncPlanEntry, error)
+}
+
+var (
+	_ ProjectAPI  = (*ProjectHandler)(nil)
+	_ StageAPI    = (*StageHandler)(nil)
+	_ ServiceAPI  = (*ServiceHandler)(nil)
+	_ ResourceAPI = (*ResourceHandler)(nil)
+)
@@ -2,12 +2,13 @@ package utils
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
-
-	"github.com/keptn/go-utils/pkg/models"
+	"time"
 )
 
 // ConfigService represents the interface for accessing the configuration service
@@ -15,78 +16,178 @@ type ConfigService interface {
 	getBaseURL() string
 	getAuthToken() string
 	getAuthHeader() string
-	getHTTPClient() *http.Client
+	// do sends the request bound to the context, so deadlines and cancellation reach the HTTP call
+	do(ctx context.Context, req *http.Request) (*http.Response, error)
+	getRetryPolicy() *RetryPolicy
+	getInstrumentation() Instrumentation
+	getAuthenticator() Authenticator
 }
 
-func post(uri string, data []byte, c ConfigService) (*models.Error, error) {
-
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	req, err := http.NewRequest("POST", uri, bytes.NewBuffer(data))
-	req.Header.Set("Content-Type", "application/json")
-	addAuthHeader(req, c)
+// doRequest sends a request to the configuration service and returns the body of a
+// successful response. Responses with a non-2xx status code are returned as *APIError.
+// Failed attempts are repeated as long as the RetryPolicy of the ConfigService permits.
+func doRequest(ctx context.Context, method string, uri string, data []byte, c ConfigService) ([]byte, error) {
+	return doRequestWithHeader(ctx, method, uri, data, nil, c)
+}
 
-	resp, err := c.getHTTPClient().Do(req)
+// doRequestWithHeader works like doRequest, but additionally sets the provided header fields
+func doRequestWithHeader(ctx context.Context, method string, uri string, data []byte, header http.Header, c ConfigService) ([]byte, error) {
+	var body bodyFunc
+	if data != nil {
+		body = func() (io.Reader, error) {
+			return bytes.NewReader(data), nil
+		}
+	}
+	resp, err := doStreamingRequest(ctx, method, uri, body, true, header, c)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		return nil, nil
+	return ioutil.ReadAll(resp.Body)
+}
+
+// bodyFunc returns the body of a request. It is called once per attempt.
+type bodyFunc func() (io.Reader, error)
+
+// doStreamingRequest sends a request to the configuration service and returns a successful
+// response, whose body has to be closed by the caller. Responses with a non-2xx status code
+// are returned as *APIError. Failed attempts are repeated as long as the RetryPolicy of the
+// ConfigService permits and the body is replayable. The request is reported to the
+// Instrumentation of the ConfigService, if any.
+func doStreamingRequest(ctx context.Context, method string, uri string, body bodyFunc, replayable bool, header http.Header, c ConfigService) (*http.Response, error) {
+	info := RequestInfo{Method: method, URI: uri}
+	return instrumentRequest(ctx, c.getInstrumentation(), info, func(ctx context.Context) (*http.Response, int, error) {
+		return retryRequest(ctx, method, uri, body, replayable, header, c)
+	})
+}
+
+// retryRequest sends a request until it succeeds or the RetryPolicy of the ConfigService does not
+// permit another attempt. It returns the response and the number of attempts.
+func retryRequest(ctx context.Context, method string, uri string, body bodyFunc, replayable bool, header http.Header, c ConfigService) (*http.Response, int, error) {
+
+	policy := c.getRetryPolicy()
+	if !replayable {
+		policy = nil
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := sendAuthenticatedRequest(ctx, method, uri, body, replayable, header, c)
+		if ctx.Err() != nil || policy == nil || !policy.shouldRetry(attempt, method, resp, err) {
+			resp, err = checkResponse(method, uri, resp, err)
+			return resp, attempt, err
+		}
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
 	}
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+// sendAuthenticatedRequest sends a request. If it is rejected with 401 and the credentials of the
+// ConfigService have been refreshed, the request is sent once more if the body is replayable.
+func sendAuthenticatedRequest(ctx context.Context, method string, uri string, body bodyFunc, replayable bool, header http.Header, c ConfigService) (*http.Response, error) {
+	resp, err := sendRequest(ctx, method, uri, body, header, c)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !replayable {
+		return resp, err
+	}
+	refreshed, err := authenticatorOf(c).Refresh(ctx)
 	if err != nil {
-		return nil, err
+		resp.Body.Close()
+		return nil, fmt.Errorf("Error when refreshing credentials after %s %s has been rejected: %s", method, uri, err.Error())
+	}
+	if !refreshed {
+		return resp, nil
 	}
+	resp.Body.Close()
+	return sendRequest(ctx, method, uri, body, header, c)
+}
 
-	var respErr models.Error
-	err = json.Unmarshal(body, &respErr)
+func sendRequest(ctx context.Context, method string, uri string, body bodyFunc, header http.Header, c ConfigService) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		var err error
+		reqBody, err = body()
+		if err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, uri, reqBody)
 	if err != nil {
+		closeBody(reqBody)
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := authenticatorOf(c).Authenticate(req); err != nil {
+		closeBody(reqBody)
 		return nil, err
 	}
 
-	return &respErr, nil
+	return c.do(ctx, req)
 }
 
-func delete(uri string, c ConfigService) (*models.Error, error) {
-
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	req, err := http.NewRequest("DELETE", uri, nil)
-	req.Header.Set("Content-Type", "application/json")
-	addAuthHeader(req, c)
+// closeBody closes the body of a request which is not sent, so that a goroutine producing it is released
+func closeBody(body io.Reader) {
+	if closer, ok := body.(io.Closer); ok {
+		closer.Close()
+	}
+}
 
-	resp, err := c.getHTTPClient().Do(req)
+// checkResponse passes on successful responses and converts all others into an *APIError
+func checkResponse(method string, uri string, resp *http.Response, err error) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		return nil, nil
+		return resp, nil
 	}
+	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
+	return nil, newAPIError(method, uri, resp.StatusCode, body)
+}
 
-	var respErr models.Error
-	err = json.Unmarshal(body, &respErr)
-	if err != nil {
-		return nil, err
-	}
+func post(ctx context.Context, uri string, data []byte, c ConfigService) error {
+	_, err := doRequest(ctx, "POST", uri, data, c)
+	return err
+}
 
-	return &respErr, nil
+func put(ctx context.Context, uri string, data []byte, c ConfigService) error {
+	_, err := doRequest(ctx, "PUT", uri, data, c)
+	return err
 }
 
-func buildErrorResponse(errorStr string) *models.Error {
-	err := models.Error{Message: &errorStr}
-	return &err
+func delete(ctx context.Context, uri string, c ConfigService) error {
+	_, err := doRequest(ctx, "DELETE", uri, nil, c)
+	return err
+}
+
+func get(ctx context.Context, uri string, c ConfigService, out interface{}) error {
+	body, err := doRequest(ctx, "GET", uri, nil, c)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
 }
 
-func addAuthHeader(req *http.Request, c ConfigService) {
-	if c.getAuthHeader() != "" && c.getAuthToken() != "" {
-		req.Header.Set(c.getAuthHeader(), c.getAuthToken())
+// authenticatorOf returns the Authenticator of the ConfigService or, if it has none,
+// a StaticTokenAuthenticator for its auth header and token
+func authenticatorOf(c ConfigService) Authenticator {
+	if authenticator := c.getAuthenticator(); authenticator != nil {
+		return authenticator
 	}
+	return NewStaticTokenAuthenticator(c.getAuthHeader(), c.getAuthToken())
 }
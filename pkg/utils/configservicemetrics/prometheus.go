@@ -0,0 +1,85 @@
+// Package configservicemetrics provides Prometheus metrics for the requests sent by the configuration service handlers.
+//
+//	metrics := configservicemetrics.NewPrometheusInstrumentation("keptn")
+//	prometheus.MustRegister(metrics)
+//	client, err := utils.NewClient(utils.WithBaseURL(url), utils.WithInstrumentation(metrics))
+package configservicemetrics
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const subsystem = "configservice"
+
+// labels of the collected metrics
+var labels = []string{"operation", "method", "code"}
+
+// PrometheusInstrumentation records the count, latency and status codes of the requests sent to the
+// configuration service per handler operation. It implements utils.Instrumentation and prometheus.Collector.
+type PrometheusInstrumentation struct {
+	requests *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewPrometheusInstrumentation returns a new PrometheusInstrumentation whose metrics are prefixed with namespace
+func NewPrometheusInstrumentation(namespace string) *PrometheusInstrumentation {
+	return &PrometheusInstrumentation{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to the configuration service.",
+		}, labels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "retries_total",
+			Help:      "Number of retried attempts of requests sent to the configuration service.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests sent to the configuration service, including retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+	}
+}
+
+// StartRequest records the metrics of a request once it has finished
+func (p *PrometheusInstrumentation) StartRequest(ctx context.Context, info utils.RequestInfo) (context.Context, func(utils.RequestResult)) {
+	return ctx, func(result utils.RequestResult) {
+		operation := info.Operation
+		if operation == "" {
+			operation = "unknown"
+		}
+		code := "error"
+		if result.StatusCode != 0 {
+			code = strconv.Itoa(result.StatusCode)
+		}
+		p.requests.WithLabelValues(operation, info.Method, code).Inc()
+		if result.Attempts > 1 {
+			p.retries.WithLabelValues(operation, info.Method, code).Add(float64(result.Attempts - 1))
+		}
+		p.duration.WithLabelValues(operation, info.Method, code).Observe(result.Duration.Seconds())
+	}
+}
+
+// Describe implements prometheus.Collector
+func (p *PrometheusInstrumentation) Describe(ch chan<- *prometheus.Desc) {
+	p.requests.Describe(ch)
+	p.retries.Describe(ch)
+	p.duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (p *PrometheusInstrumentation) Collect(ch chan<- prometheus.Metric) {
+	p.requests.Collect(ch)
+	p.retries.Collect(ch)
+	p.duration.Collect(ch)
+}
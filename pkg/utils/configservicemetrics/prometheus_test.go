@@ -0,0 +1,56 @@
+package configservicemetrics_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/go-utils/pkg/utils/configservicemetrics"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusInstrumentation(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.AddProject("sockshop")
+	srv.InjectFault(configservicetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 2})
+
+	metrics := configservicemetrics.NewPrometheusInstrumentation("keptn")
+	client, err := utils.NewClient(
+		utils.WithBaseURL(srv.URL),
+		utils.WithInstrumentation(metrics),
+		utils.WithRetryPolicy(&utils.RetryPolicy{
+			MaxAttempts:          3,
+			InitialBackoff:       time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Projects().GetProject(models.Project{ProjectName: "sockshop"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Projects().GetProject(models.Project{ProjectName: "unknown"}); !utils.IsNotFoundError(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+
+	// the retried request is counted once with the status code of its last attempt
+	const want = `
+# HELP keptn_configservice_requests_total Number of requests sent to the configuration service.
+# TYPE keptn_configservice_requests_total counter
+keptn_configservice_requests_total{code="200",method="GET",operation="GetProject"} 1
+keptn_configservice_requests_total{code="404",method="GET",operation="GetProject"} 1
+# HELP keptn_configservice_retries_total Number of retried attempts of requests sent to the configuration service.
+# TYPE keptn_configservice_retries_total counter
+keptn_configservice_retries_total{code="200",method="GET",operation="GetProject"} 2
+`
+	if err := testutil.CollectAndCompare(metrics, strings.NewReader(want), "keptn_configservice_requests_total", "keptn_configservice_retries_total"); err != nil {
+		t.Error(err)
+	}
+}
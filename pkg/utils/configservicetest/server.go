@@ -0,0 +1,827 @@
+// Package configservicetest provides an in-memory fake of the keptn configuration service,
+// which allows testing code using the config service handlers without a running keptn installation.
+//
+//	srv := configservicetest.NewServer()
+//	defer srv.Close()
+//	srv.AddProject("sockshop")
+//	rh := utils.NewResourceHandler(srv.URL)
+package configservicetest
+
+import (
+	"crypto/sha1"
+	b64 "encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+// DefaultPageSize is the page size used by list endpoints if the request does not specify one
+const DefaultPageSize = 20
+
+// Fault describes a failure injected into the responses of the fake
+type Fault struct {
+	// Method restricts the fault to requests with this HTTP method. If empty, all methods are affected.
+	Method string
+	// PathPrefix restricts the fault to requests whose path starts with the prefix
+	PathPrefix string
+	// StatusCode is returned instead of handling the request. If 0, the request is handled after Delay.
+	StatusCode int
+	// Header is added to the faulty response, e.g. Retry-After
+	Header http.Header
+	// Delay is waited before responding
+	Delay time.Duration
+	// Times limits the number of affected requests. If 0, all matching requests are affected.
+	Times int
+}
+
+// Server is an in-memory fake of the configuration service
+type Server struct {
+	*httptest.Server
+
+	// PageSize is used by list endpoints if the request does not specify a page size
+	PageSize int
+
+	mu         sync.Mutex
+	projects   map[string]*project
+	order      []string
+	faults     []*Fault
+	authHeader string
+	authToken  string
+	requests   int
+	commits    int
+}
+
+type project struct {
+	models.Project
+	resources *resourceStore
+	stages    map[string]*stage
+	order     []string
+}
+
+type stage struct {
+	name      string
+	resources *resourceStore
+	services  map[string]*service
+	order     []string
+}
+
+type service struct {
+	name      string
+	resources *resourceStore
+}
+
+type resourceStore struct {
+	// versions contains the history of each resource, latest version last
+	versions map[string][]*resourceVersion
+}
+
+type resourceVersion struct {
+	version string
+	content []byte
+	deleted bool
+}
+
+type resourceRequest struct {
+	Resources []*models.Resource `json:"resources"`
+}
+
+// NewServer starts a new fake configuration service. It has to be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		PageSize: DefaultPageSize,
+		projects: map[string]*project{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// RequireAuth rejects all requests which do not contain the token in the provided header
+func (s *Server) RequireAuth(authHeader string, authToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authHeader = authHeader
+	s.authToken = authToken
+}
+
+// InjectFault adds a fault to the responses of the fake
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// RequestCount returns the number of requests received so far
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// AddProject adds a project. An existing project is left unchanged.
+func (s *Server) AddProject(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureProject(name)
+}
+
+// AddStage adds a stage to a project, which is created if necessary. An existing stage is left unchanged.
+func (s *Server) AddStage(projectName string, stageName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureStage(s.ensureProject(projectName), stageName)
+}
+
+// AddService adds a service to a stage, which is created if necessary. An existing service is left unchanged.
+func (s *Server) AddService(projectName string, stageName string, serviceName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureService(s.ensureStage(s.ensureProject(projectName), stageName), serviceName)
+}
+
+// SetResource stores a resource and returns its new version. The resource belongs to the project if
+// stageName is empty, to the stage if serviceName is empty and to the service otherwise. Missing
+// projects, stages and services are created.
+func (s *Server) SetResource(projectName string, stageName string, serviceName string, resourceURI string, content []byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.ensureProject(projectName)
+	store := p.resources
+	if stageName != "" {
+		st := s.ensureStage(p, stageName)
+		store = st.resources
+		if serviceName != "" {
+			store = s.ensureService(st, serviceName).resources
+		}
+	}
+	return s.write(store, resourceURI, content)
+}
+
+// GetResource returns the latest content of a resource and whether it exists
+func (s *Server) GetResource(projectName string, stageName string, serviceName string, resourceURI string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.projects[projectName]
+	if !ok {
+		return nil, false
+	}
+	store := p.resources
+	if stageName != "" {
+		st, ok := p.stages[stageName]
+		if !ok {
+			return nil, false
+		}
+		store = st.resources
+		if serviceName != "" {
+			svc, ok := st.services[serviceName]
+			if !ok {
+				return nil, false
+			}
+			store = svc.resources
+		}
+	}
+	v := store.latest(resourceURI)
+	if v == nil {
+		return nil, false
+	}
+	return v.content, true
+}
+
+func (s *Server) ensureProject(name string) *project {
+	if p, ok := s.projects[name]; ok {
+		return p
+	}
+	return s.addProject(models.Project{ProjectName: name})
+}
+
+func (s *Server) ensureStage(p *project, name string) *stage {
+	if st, ok := p.stages[name]; ok {
+		return st
+	}
+	return s.addStage(p, name)
+}
+
+func (s *Server) ensureService(st *stage, name string) *service {
+	if svc, ok := st.services[name]; ok {
+		return svc
+	}
+	return s.addService(st, name)
+}
+
+func (s *Server) addProject(p models.Project) *project {
+	newProject := &project{Project: p, resources: newResourceStore(), stages: map[string]*stage{}}
+	s.projects[p.ProjectName] = newProject
+	s.order = append(s.order, p.ProjectName)
+	return newProject
+}
+
+func (s *Server) addStage(p *project, name string) *stage {
+	newStage := &stage{name: name, resources: newResourceStore(), services: map[string]*service{}}
+	p.stages[name] = newStage
+	p.order = append(p.order, name)
+	return newStage
+}
+
+func (s *Server) addService(st *stage, name string) *service {
+	newService := &service{name: name, resources: newResourceStore()}
+	st.services[name] = newService
+	st.order = append(st.order, name)
+	return newService
+}
+
+func newResourceStore() *resourceStore {
+	return &resourceStore{versions: map[string][]*resourceVersion{}}
+}
+
+func (rs *resourceStore) latest(uri string) *resourceVersion {
+	versions := rs.versions[uri]
+	if len(versions) == 0 || versions[len(versions)-1].deleted {
+		return nil
+	}
+	return versions[len(versions)-1]
+}
+
+func (rs *resourceStore) uris() []string {
+	uris := []string{}
+	for uri := range rs.versions {
+		if rs.latest(uri) != nil {
+			uris = append(uris, uri)
+		}
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// newVersion returns a new commit ID
+func (s *Server) newVersion() string {
+	s.commits++
+	sum := sha1.Sum([]byte(strconv.Itoa(s.commits)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Server) write(rs *resourceStore, uri string, content []byte) string {
+	version := s.newVersion()
+	rs.versions[uri] = append(rs.versions[uri], &resourceVersion{version: version, content: content})
+	return version
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	fault := s.matchFault(r)
+	authHeader, authToken := s.authHeader, s.authToken
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Delay > 0 {
+			select {
+			case <-time.After(fault.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.StatusCode != 0 {
+			for key, values := range fault.Header {
+				w.Header()[key] = values
+			}
+			writeError(w, fault.StatusCode, "injected fault")
+			return
+		}
+	}
+	if authHeader != "" && r.Header.Get(authHeader) != authToken {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	segments, err := splitPath(r.URL.EscapedPath())
+	if err != nil || len(segments) < 2 || segments[0] != "v1" || segments[1] != "project" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.route(w, r, segments[2:])
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && !strings.EqualFold(fault.Method, r.Method) {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.PathPrefix) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func splitPath(escapedPath string) ([]string, error) {
+	segments := []string{}
+	for _, segment := range strings.Split(strings.Trim(escapedPath, "/"), "/") {
+		unescaped, err := url.QueryUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, unescaped)
+	}
+	return segments, nil
+}
+
+// route dispatches a request for the path below /v1/project
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 1 && segments[0] == "" {
+		segments = nil
+	}
+	if len(segments) == 0 {
+		s.handleProjects(w, r)
+		return
+	}
+
+	p, ok := s.projects[segments[0]]
+	if !ok && !(len(segments) == 1 && r.Method == "PUT") {
+		writeError(w, http.StatusNotFound, "project "+segments[0]+" not found")
+		return
+	}
+	switch {
+	case len(segments) == 1:
+		s.handleProject(w, r, segments[0], p)
+	case segments[1] == "resource":
+		s.handleResources(w, r, p.resources, segments[2:])
+	case segments[1] == "stage" && len(segments) == 2:
+		s.handleStages(w, r, p)
+	case segments[1] == "stage":
+		st, ok := p.stages[segments[2]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "stage "+segments[2]+" not found")
+			return
+		}
+		s.routeStage(w, r, p, st, segments[3:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// routeStage dispatches a request for the path below /v1/project/{project}/stage/{stage}
+func (s *Server) routeStage(w http.ResponseWriter, r *http.Request, p *project, st *stage, segments []string) {
+	switch {
+	case len(segments) == 0:
+		s.handleStage(w, r, p, st)
+	case segments[0] == "resource":
+		s.handleResources(w, r, st.resources, segments[1:])
+	case segments[0] == "service" && len(segments) == 1:
+		s.handleServices(w, r, st)
+	case segments[0] == "service":
+		svc, ok := st.services[segments[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "service "+segments[1]+" not found")
+			return
+		}
+		switch {
+		case len(segments) == 2:
+			s.handleService(w, r, st, svc)
+		case segments[2] == "resource":
+			s.handleResources(w, r, svc.resources, segments[3:])
+		default:
+			writeError(w, http.StatusNotFound, "not found")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		projects := []*models.Project{}
+		for _, name := range s.order {
+			p := s.projects[name].Project
+			p.GitToken = ""
+			projects = append(projects, &p)
+		}
+		start, end, next, ok := s.page(w, r, len(projects))
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, &models.Projects{
+			Projects:    projects[start:end],
+			NextPageKey: next,
+			PageSize:    float64(end - start),
+			TotalCount:  float64(len(projects)),
+		})
+	case "POST":
+		var p models.Project
+		if !readJSON(w, r, &p) {
+			return
+		}
+		if p.ProjectName == "" {
+			writeError(w, http.StatusBadRequest, "project name missing")
+			return
+		}
+		if _, ok := s.projects[p.ProjectName]; ok {
+			writeError(w, http.StatusConflict, "project "+p.ProjectName+" already exists")
+			return
+		}
+		s.addProject(p)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleProject(w http.ResponseWriter, r *http.Request, name string, p *project) {
+	switch r.Method {
+	case "GET":
+		respProject := p.Project
+		respProject.GitToken = ""
+		writeJSON(w, http.StatusOK, &respProject)
+	case "PUT":
+		var update models.Project
+		if !readJSON(w, r, &update) {
+			return
+		}
+		update.ProjectName = name
+		if p == nil {
+			s.addProject(update)
+		} else {
+			p.Project = update
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "DELETE":
+		delete(s.projects, name)
+		s.order = removeName(s.order, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleStages(w http.ResponseWriter, r *http.Request, p *project) {
+	switch r.Method {
+	case "GET":
+		stages := []*models.Stage{}
+		for _, name := range p.order {
+			stages = append(stages, &models.Stage{StageName: name})
+		}
+		start, end, next, ok := s.page(w, r, len(stages))
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, &models.Stages{
+			Stages:      stages[start:end],
+			NextPageKey: next,
+			PageSize:    float64(end - start),
+			TotalCount:  float64(len(stages)),
+		})
+	case "POST":
+		var st models.Stage
+		if !readJSON(w, r, &st) {
+			return
+		}
+		if st.StageName == "" {
+			writeError(w, http.StatusBadRequest, "stage name missing")
+			return
+		}
+		if _, ok := p.stages[st.StageName]; ok {
+			writeError(w, http.StatusConflict, "stage "+st.StageName+" already exists")
+			return
+		}
+		s.addStage(p, st.StageName)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleStage(w http.ResponseWriter, r *http.Request, p *project, st *stage) {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, &models.Stage{StageName: st.name})
+	case "PUT":
+		var update models.Stage
+		if !readJSON(w, r, &update) {
+			return
+		}
+		if update.StageName != "" && update.StageName != st.name {
+			if _, ok := p.stages[update.StageName]; ok {
+				writeError(w, http.StatusConflict, "stage "+update.StageName+" already exists")
+				return
+			}
+			delete(p.stages, st.name)
+			for i, name := range p.order {
+				if name == st.name {
+					p.order[i] = update.StageName
+				}
+			}
+			st.name = update.StageName
+			p.stages[st.name] = st
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "DELETE":
+		delete(p.stages, st.name)
+		p.order = removeName(p.order, st.name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleServices(w http.ResponseWriter, r *http.Request, st *stage) {
+	switch r.Method {
+	case "GET":
+		services := []*models.Service{}
+		for _, name := range st.order {
+			services = append(services, &models.Service{ServiceName: name})
+		}
+		start, end, next, ok := s.page(w, r, len(services))
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, &models.Services{
+			Services:    services[start:end],
+			NextPageKey: next,
+			PageSize:    float64(end - start),
+			TotalCount:  float64(len(services)),
+		})
+	case "POST":
+		var svc models.Service
+		if !readJSON(w, r, &svc) {
+			return
+		}
+		if svc.ServiceName == "" {
+			writeError(w, http.StatusBadRequest, "service name missing")
+			return
+		}
+		if _, ok := st.services[svc.ServiceName]; ok {
+			writeError(w, http.StatusConflict, "service "+svc.ServiceName+" already exists")
+			return
+		}
+		s.addService(st, svc.ServiceName)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleService(w http.ResponseWriter, r *http.Request, st *stage, svc *service) {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, &models.Service{ServiceName: svc.name})
+	case "PUT":
+		var update models.Service
+		if !readJSON(w, r, &update) {
+			return
+		}
+		if update.ServiceName != "" && update.ServiceName != svc.name {
+			if _, ok := st.services[update.ServiceName]; ok {
+				writeError(w, http.StatusConflict, "service "+update.ServiceName+" already exists")
+				return
+			}
+			delete(st.services, svc.name)
+			for i, name := range st.order {
+				if name == svc.name {
+					st.order[i] = update.ServiceName
+				}
+			}
+			svc.name = update.ServiceName
+			st.services[svc.name] = svc
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "DELETE":
+		delete(st.services, svc.name)
+		st.order = removeName(st.order, svc.name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// handleResources handles the resource collection of a store (segments is empty), a single
+// resource (one segment) and the version history of a resource (segments ending with "version")
+func (s *Server) handleResources(w http.ResponseWriter, r *http.Request, rs *resourceStore, segments []string) {
+	switch {
+	case len(segments) == 0:
+		s.handleResourceCollection(w, r, rs)
+	case len(segments) == 1:
+		s.handleResource(w, r, rs, segments[0])
+	case len(segments) == 2 && segments[1] == "version" && r.Method == "GET":
+		s.handleResourceVersions(w, r, rs, segments[0])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) handleResourceCollection(w http.ResponseWriter, r *http.Request, rs *resourceStore) {
+	switch r.Method {
+	case "GET":
+		uris := rs.uris()
+		start, end, next, ok := s.page(w, r, len(uris))
+		if !ok {
+			return
+		}
+		resources := []*models.VersionedResource{}
+		for _, uri := range uris[start:end] {
+			resources = append(resources, toResource(uri, rs.latest(uri)))
+		}
+		writeJSON(w, http.StatusOK, &models.VersionedResources{
+			Resources:   resources,
+			NextPageKey: next,
+			PageSize:    float64(end - start),
+			TotalCount:  float64(len(uris)),
+		})
+	case "POST", "PUT":
+		var req resourceRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		contents := map[string][]byte{}
+		for _, resource := range req.Resources {
+			if resource.ResourceURI == nil {
+				writeError(w, http.StatusBadRequest, "resource URI missing")
+				return
+			}
+			content, err := b64.StdEncoding.DecodeString(resource.ResourceContent)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "resource content is not base64 encoded")
+				return
+			}
+			contents[*resource.ResourceURI] = content
+		}
+		// all resources of a request are stored in a single commit
+		version := s.newVersion()
+		for uri, content := range contents {
+			rs.versions[uri] = append(rs.versions[uri], &resourceVersion{version: version, content: content})
+		}
+		writeJSON(w, http.StatusCreated, &models.Version{Version: version})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleResource(w http.ResponseWriter, r *http.Request, rs *resourceStore, uri string) {
+	switch r.Method {
+	case "GET":
+		v := rs.latest(uri)
+		if version := r.URL.Query().Get("version"); version != "" {
+			v = nil
+			for _, candidate := range rs.versions[uri] {
+				if candidate.version == version && !candidate.deleted {
+					v = candidate
+				}
+			}
+		}
+		if v == nil {
+			writeError(w, http.StatusNotFound, "resource "+uri+" not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, toResource(uri, v))
+	case "PUT":
+		if expected := r.Header.Get("If-Match"); expected != "" {
+			if latest := rs.latest(uri); latest == nil || latest.version != expected {
+				writeError(w, http.StatusPreconditionFailed, "resource "+uri+" has been modified")
+				return
+			}
+		}
+		var resource models.Resource
+		if !readJSON(w, r, &resource) {
+			return
+		}
+		content, err := b64.StdEncoding.DecodeString(resource.ResourceContent)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "resource content is not base64 encoded")
+			return
+		}
+		writeJSON(w, http.StatusCreated, &models.Version{Version: s.write(rs, uri, content)})
+	case "DELETE":
+		if rs.latest(uri) == nil {
+			writeError(w, http.StatusNotFound, "resource "+uri+" not found")
+			return
+		}
+		version := s.newVersion()
+		rs.versions[uri] = append(rs.versions[uri], &resourceVersion{version: version, deleted: true})
+		writeJSON(w, http.StatusOK, &models.Version{Version: version})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleResourceVersions(w http.ResponseWriter, r *http.Request, rs *resourceStore, uri string) {
+	history := rs.versions[uri]
+	if len(history) == 0 {
+		writeError(w, http.StatusNotFound, "resource "+uri+" not found")
+		return
+	}
+	versions := []*models.Version{}
+	for i := len(history) - 1; i >= 0; i-- {
+		if !history[i].deleted {
+			versions = append(versions, &models.Version{Version: history[i].version})
+		}
+	}
+	start, end, next, ok := s.page(w, r, len(versions))
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &models.Versions{
+		Versions:    versions[start:end],
+		NextPageKey: next,
+		PageSize:    float64(end - start),
+		TotalCount:  float64(len(versions)),
+	})
+}
+
+// page returns the bounds of the requested page and the key of the next page
+func (s *Server) page(w http.ResponseWriter, r *http.Request, total int) (int, int, string, bool) {
+	pageSize := s.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if value := r.URL.Query().Get("pageSize"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid pageSize")
+			return 0, 0, "", false
+		}
+		pageSize = size
+	}
+	start := 0
+	if value := r.URL.Query().Get("nextPageKey"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 || offset > total {
+			writeError(w, http.StatusBadRequest, "invalid nextPageKey")
+			return 0, 0, "", false
+		}
+		start = offset
+	}
+	end := start + pageSize
+	if end >= total {
+		return start, total, "", true
+	}
+	return start, end, strconv.Itoa(end), true
+}
+
+func toResource(uri string, v *resourceVersion) *models.VersionedResource {
+	resourceURI := uri
+	return &models.VersionedResource{
+		Resource: models.Resource{
+			ResourceURI:     &resourceURI,
+			ResourceContent: b64.StdEncoding.EncodeToString(v.content),
+		},
+		Metadata: &models.Version{Version: v.version},
+	}
+}
+
+func removeName(names []string, name string) []string {
+	result := []string{}
+	for _, n := range names {
+		if n != name {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, out interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, &models.Error{Code: int64(statusCode), Message: &message})
+}
@@ -0,0 +1,302 @@
+package configservicetest_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+)
+
+func send(t *testing.T, srv *configservicetest.Server, method string, path string, body string, header http.Header) (int, string) {
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(respBody)
+}
+
+func TestServerRoutes(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.AddService("sockshop", "dev", "carts")
+	version := srv.SetResource("sockshop", "dev", "carts", "helm/carts.tgz", []byte("chart"))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		header     http.Header
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "list projects", method: "GET", path: "/v1/project", wantStatus: http.StatusOK, wantBody: `"projectName":"sockshop"`},
+		{name: "get unknown project", method: "GET", path: "/v1/project/unknown", wantStatus: http.StatusNotFound},
+		{name: "create existing project", method: "POST", path: "/v1/project", body: `{"projectName":"sockshop"}`, wantStatus: http.StatusConflict},
+		{name: "create project without name", method: "POST", path: "/v1/project", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "create project with invalid body", method: "POST", path: "/v1/project", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "list stages", method: "GET", path: "/v1/project/sockshop/stage", wantStatus: http.StatusOK, wantBody: `"stageName":"dev"`},
+		{name: "create existing stage", method: "POST", path: "/v1/project/sockshop/stage", body: `{"stageName":"dev"}`, wantStatus: http.StatusConflict},
+		{name: "get unknown stage", method: "GET", path: "/v1/project/sockshop/stage/prod", wantStatus: http.StatusNotFound},
+		{name: "list services", method: "GET", path: "/v1/project/sockshop/stage/dev/service", wantStatus: http.StatusOK, wantBody: `"serviceName":"carts"`},
+		{name: "create existing service", method: "POST", path: "/v1/project/sockshop/stage/dev/service", body: `{"serviceName":"carts"}`, wantStatus: http.StatusConflict},
+		{name: "get unknown service", method: "GET", path: "/v1/project/sockshop/stage/dev/service/orders", wantStatus: http.StatusNotFound},
+		{name: "get resource", method: "GET", path: "/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fcarts.tgz", wantStatus: http.StatusOK, wantBody: `"version":"` + version + `"`},
+		{name: "get resource at unknown version", method: "GET", path: "/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fcarts.tgz?version=0", wantStatus: http.StatusNotFound},
+		{name: "list resource versions", method: "GET", path: "/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fcarts.tgz/version", wantStatus: http.StatusOK, wantBody: version},
+		{name: "update resource with stale version", method: "PUT", path: "/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fcarts.tgz",
+			body: `{"resourceURI":"helm/carts.tgz","resourceContent":"YQ=="}`, header: http.Header{"If-Match": []string{"0"}}, wantStatus: http.StatusPreconditionFailed},
+		{name: "update resource with invalid content", method: "PUT", path: "/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fcarts.tgz",
+			body: `{"resourceURI":"helm/carts.tgz","resourceContent":"!"}`, wantStatus: http.StatusBadRequest},
+		{name: "delete unknown resource", method: "DELETE", path: "/v1/project/sockshop/resource/unknown.yaml", wantStatus: http.StatusNotFound},
+		{name: "invalid page size", method: "GET", path: "/v1/project?pageSize=0", wantStatus: http.StatusBadRequest},
+		{name: "invalid nextPageKey", method: "GET", path: "/v1/project?nextPageKey=x", wantStatus: http.StatusBadRequest},
+		{name: "unknown path", method: "GET", path: "/v2/project", wantStatus: http.StatusNotFound},
+		{name: "unsupported method", method: "PATCH", path: "/v1/project", wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := send(t, srv, tt.method, tt.path, tt.body, tt.header)
+			if status != tt.wantStatus {
+				t.Errorf("got status %d, want %d: %s", status, tt.wantStatus, body)
+			}
+			if !strings.Contains(body, tt.wantBody) {
+				t.Errorf("got body %s, want it to contain %s", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestServerPaging(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 2
+	for _, name := range []string{"a", "b", "c"} {
+		srv.AddProject(name)
+	}
+
+	tests := []struct {
+		query       string
+		wantNames   []string
+		wantNextKey string
+	}{
+		{query: "", wantNames: []string{"a", "b"}, wantNextKey: "2"},
+		{query: "?nextPageKey=2", wantNames: []string{"c"}},
+		{query: "?pageSize=5", wantNames: []string{"a", "b", "c"}},
+		{query: "?pageSize=1&nextPageKey=1", wantNames: []string{"b"}, wantNextKey: "2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			status, body := send(t, srv, "GET", "/v1/project"+tt.query, "", nil)
+			if status != http.StatusOK {
+				t.Fatalf("got status %d: %s", status, body)
+			}
+			var projects models.Projects
+			if err := json.Unmarshal([]byte(body), &projects); err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, p := range projects.Projects {
+				names = append(names, p.ProjectName)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("got projects %v, want %v", names, tt.wantNames)
+			}
+			if projects.NextPageKey != tt.wantNextKey {
+				t.Errorf("got nextPageKey %q, want %q", projects.NextPageKey, tt.wantNextKey)
+			}
+			if projects.TotalCount != 3 {
+				t.Errorf("got total count %g, want 3", projects.TotalCount)
+			}
+		})
+	}
+}
+
+func TestServerAddKeepsExistingEntries(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.AddProject("sockshop")
+	srv.AddProject("podtato")
+	srv.AddStage("sockshop", "dev")
+	srv.AddStage("sockshop", "prod")
+	srv.SetResource("sockshop", "", "", "shipyard.yaml", []byte("shipyard"))
+	srv.SetResource("sockshop", "dev", "", "stage.yaml", []byte("stage"))
+	srv.SetResource("sockshop", "dev", "carts", "values.yaml", []byte("service"))
+
+	srv.AddProject("sockshop")
+	srv.AddStage("sockshop", "dev")
+	srv.AddService("sockshop", "dev", "carts")
+
+	lists := []struct {
+		path string
+		want string
+	}{
+		{path: "/v1/project", want: `"projectName":"sockshop".*"projectName":"podtato"`},
+		{path: "/v1/project/sockshop/stage", want: `"stageName":"dev".*"stageName":"prod"`},
+	}
+	for _, list := range lists {
+		status, body := send(t, srv, "GET", list.path, "", nil)
+		if status != http.StatusOK || !regexp.MustCompile(list.want).MatchString(body) {
+			t.Errorf("got %s with status %d, want %s in the original order", body, status, list.want)
+		}
+		if strings.Count(body, "Name\":") != 2 {
+			t.Errorf("got %s, want two entries", body)
+		}
+	}
+	resources := []struct {
+		stage   string
+		service string
+		uri     string
+		want    string
+	}{
+		{uri: "shipyard.yaml", want: "shipyard"},
+		{stage: "dev", uri: "stage.yaml", want: "stage"},
+		{stage: "dev", service: "carts", uri: "values.yaml", want: "service"},
+	}
+	for _, resource := range resources {
+		if content, ok := srv.GetResource("sockshop", resource.stage, resource.service, resource.uri); !ok || string(content) != resource.want {
+			t.Errorf("got content %q of resource %s, want %q", content, resource.uri, resource.want)
+		}
+	}
+}
+
+func TestServerResourceHistory(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	v1 := srv.SetResource("sockshop", "", "", "shipyard.yaml", []byte("a"))
+	v2 := srv.SetResource("sockshop", "", "", "shipyard.yaml", []byte("b"))
+	if v1 == v2 {
+		t.Fatalf("got version %s twice, want a new version per write", v1)
+	}
+	if content, ok := srv.GetResource("sockshop", "", "", "shipyard.yaml"); !ok || string(content) != "b" {
+		t.Errorf("got content %q, want the latest content", content)
+	}
+
+	if status, body := send(t, srv, "DELETE", "/v1/project/sockshop/resource/shipyard.yaml", "", nil); status != http.StatusOK {
+		t.Fatalf("got status %d: %s", status, body)
+	}
+	if _, ok := srv.GetResource("sockshop", "", "", "shipyard.yaml"); ok {
+		t.Error("got deleted resource, want none")
+	}
+	if status, _ := send(t, srv, "GET", "/v1/project/sockshop/resource/shipyard.yaml?version="+v1, "", nil); status != http.StatusOK {
+		t.Errorf("got status %d for an earlier version of a deleted resource, want %d", status, http.StatusOK)
+	}
+	if _, ok := srv.GetResource("unknown", "dev", "carts", "shipyard.yaml"); ok {
+		t.Error("got resource of an unknown project, want none")
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.AddProject("sockshop")
+	srv.InjectFault(configservicetest.Fault{
+		Method:     "GET",
+		PathPrefix: "/v1/project/sockshop",
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{"1"}},
+		Times:      2,
+	})
+
+	tests := []struct {
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{method: "GET", path: "/v1/project", wantStatus: http.StatusOK},
+		{method: "PUT", path: "/v1/project/sockshop", wantStatus: http.StatusNoContent},
+		{method: "GET", path: "/v1/project/sockshop", wantStatus: http.StatusServiceUnavailable},
+		{method: "GET", path: "/v1/project/sockshop", wantStatus: http.StatusServiceUnavailable},
+		{method: "GET", path: "/v1/project/sockshop", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		if status, respBody := send(t, srv, tt.method, tt.path, "{}", nil); status != tt.wantStatus {
+			t.Errorf("%s %s: got status %d, want %d: %s", tt.method, tt.path, status, tt.wantStatus, respBody)
+		}
+	}
+	if srv.RequestCount() != len(tests) {
+		t.Errorf("got %d requests, want %d", srv.RequestCount(), len(tests))
+	}
+
+	srv.InjectFault(configservicetest.Fault{StatusCode: http.StatusInternalServerError})
+	srv.ClearFaults()
+	if status, _ := send(t, srv, "GET", "/v1/project", "", nil); status != http.StatusOK {
+		t.Errorf("got status %d after clearing the faults, want %d", status, http.StatusOK)
+	}
+}
+
+func TestServerDelayIsCancelled(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(configservicetest.Fault{Delay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequest("GET", srv.URL+"/v1/project", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := http.DefaultClient.Do(req.WithContext(ctx)); err == nil {
+		t.Error("got no error, want the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request took %s, want it to be cancelled", elapsed)
+	}
+}
+
+func TestServerRequireAuth(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.AddProject("sockshop")
+	srv.RequireAuth("x-token", "secret")
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		wantStatus int
+	}{
+		{name: "no token", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", header: http.Header{"X-Token": []string{"wrong"}}, wantStatus: http.StatusUnauthorized},
+		{name: "valid token", header: http.Header{"X-Token": []string{"secret"}}, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, body := send(t, srv, "GET", "/v1/project/sockshop", "", tt.header); status != tt.wantStatus {
+				t.Errorf("got status %d, want %d: %s", status, tt.wantStatus, body)
+			}
+		})
+	}
+}
+
+func TestServerHidesGitToken(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	if status, body := send(t, srv, "POST", "/v1/project", `{"projectName":"sockshop","gitToken":"secret"}`, nil); status != http.StatusNoContent {
+		t.Fatalf("got status %d: %s", status, body)
+	}
+	for _, path := range []string{"/v1/project", "/v1/project/sockshop"} {
+		if _, body := send(t, srv, "GET", path, "", nil); strings.Contains(body, "secret") {
+			t.Errorf("GET %s returned the git token: %s", path, body)
+		}
+	}
+}
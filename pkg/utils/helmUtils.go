@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -23,9 +24,7 @@ func getHelmChartURI(chartName string) string {
 }
 
 // StoreChart stores a chart in the configuration service
-func StoreChart(project string, service string, stage string, chartName string, helmChart []byte, configServiceURL string) error {
-	resourceHandler := NewResourceHandler(configServiceURL)
-
+func StoreChart(project string, service string, stage string, chartName string, helmChart []byte, resourceHandler ResourceAPI) error {
 	uri := getHelmChartURI(chartName)
 	resource := models.Resource{ResourceURI: &uri, ResourceContent: string(helmChart)}
 
@@ -38,16 +37,15 @@ func StoreChart(project string, service string, stage string, chartName string,
 }
 
 // GetChart reads the chart from the configuration service
-func GetChart(project string, service string, stage string, chartName string, configServiceURL string) (*chart.Chart, error) {
-	resourceHandler := NewResourceHandler(configServiceURL)
-
-	resource, err := resourceHandler.GetServiceResource(project, stage, service, getHelmChartURI(chartName))
+func GetChart(project string, service string, stage string, chartName string, resourceHandler ResourceAPI) (*chart.Chart, error) {
+	helmChart, err := resourceHandler.GetResourceContent(context.Background(),
+		NewServiceScope(project, stage, service), getHelmChartURI(chartName), "")
 	if err != nil {
 		return nil, fmt.Errorf("Error when reading chart %s from project %s: %s",
 			chartName, project, err.Error())
 	}
 
-	ch, err := LoadChart([]byte(resource.ResourceContent))
+	ch, err := LoadChart(helmChart)
 	if err != nil {
 		return nil, fmt.Errorf("Error when reading chart %s from project %s: %s",
 			chartName, project, err.Error())
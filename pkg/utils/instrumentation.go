@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes a request sent to the configuration service
+type RequestInfo struct {
+	// Operation is the handler method on whose behalf the request is sent, e.g. GetServiceResource
+	Operation string
+	// Method is the HTTP method of the request
+	Method string
+	// URI is the URI of the request
+	URI string
+	// KeptnContext is the keptn context attached to the context of the request, if any
+	KeptnContext string
+}
+
+// RequestResult describes the outcome of a request sent to the configuration service
+type RequestResult struct {
+	// StatusCode is the HTTP status code of the last response. It is 0 if no response has been received.
+	StatusCode int
+	// Attempts is the number of attempts, including retries
+	Attempts int
+	// Duration is the time from sending the first attempt until the response headers of the last attempt have
+	// been received, including the backoff between the attempts
+	Duration time.Duration
+	// Err is the error returned to the caller, if any
+	Err error
+}
+
+// Instrumentation observes the requests sent by the configuration service handlers,
+// e.g. for recording metrics or creating spans
+type Instrumentation interface {
+	// StartRequest is called before a request is sent. The returned context is used for sending the
+	// request and the returned function is called once the request has finished.
+	StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult))
+}
+
+type operationKey struct{}
+
+type keptnContextKey struct{}
+
+// withOperation attributes the requests sent with the returned context to the handler operation.
+// An operation which has already been set is kept, so requests of nested handler calls are
+// attributed to the outermost operation.
+func withOperation(ctx context.Context, operation string) context.Context {
+	if ctx.Value(operationKey{}) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+func operationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// ContextWithKeptnContext returns a context carrying the keptn context of the event being processed.
+// The keptn context is passed on to the Instrumentation of requests sent with the returned context.
+func ContextWithKeptnContext(ctx context.Context, keptnContext string) context.Context {
+	return context.WithValue(ctx, keptnContextKey{}, keptnContext)
+}
+
+// KeptnContextFromContext returns the keptn context attached by ContextWithKeptnContext
+func KeptnContextFromContext(ctx context.Context) string {
+	keptnContext, _ := ctx.Value(keptnContextKey{}).(string)
+	return keptnContext
+}
+
+// instrumentRequest sends a request via send and reports it to the instrumentation, if any
+func instrumentRequest(ctx context.Context, instrumentation Instrumentation, info RequestInfo,
+	send func(ctx context.Context) (*http.Response, int, error)) (*http.Response, error) {
+
+	if instrumentation == nil {
+		resp, _, err := send(ctx)
+		return resp, err
+	}
+	info.Operation = operationFromContext(ctx)
+	info.KeptnContext = KeptnContextFromContext(ctx)
+	ctx, finish := instrumentation.StartRequest(ctx, info)
+
+	start := time.Now()
+	resp, attempts, err := send(ctx)
+	result := RequestResult{
+		Attempts: attempts,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	var apiErr *APIError
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	} else if errors.As(err, &apiErr) {
+		result.StatusCode = apiErr.StatusCode
+	}
+	finish(result)
+	return resp, err
+}
+
+// multiInstrumentation passes requests on to multiple instrumentations
+type multiInstrumentation []Instrumentation
+
+// NewMultiInstrumentation returns an Instrumentation which passes requests on to all provided instrumentations
+func NewMultiInstrumentation(instrumentations ...Instrumentation) Instrumentation {
+	return multiInstrumentation(instrumentations)
+}
+
+func (m multiInstrumentation) StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult)) {
+	finishers := make([]func(RequestResult), 0, len(m))
+	for _, instrumentation := range m {
+		var finish func(RequestResult)
+		ctx, finish = instrumentation.StartRequest(ctx, info)
+		finishers = append(finishers, finish)
+	}
+	return ctx, func(result RequestResult) {
+		for i := len(finishers) - 1; i >= 0; i-- {
+			finishers[i](result)
+		}
+	}
+}
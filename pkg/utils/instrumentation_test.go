@@ -0,0 +1,198 @@
+package utils_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+)
+
+// recordingInstrumentation records the requests reported to it
+type recordingInstrumentation struct {
+	mu      sync.Mutex
+	infos   []utils.RequestInfo
+	results []utils.RequestResult
+}
+
+func (r *recordingInstrumentation) StartRequest(ctx context.Context, info utils.RequestInfo) (context.Context, func(utils.RequestResult)) {
+	r.mu.Lock()
+	r.infos = append(r.infos, info)
+	r.mu.Unlock()
+	return ctx, func(result utils.RequestResult) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.results = append(r.results, result)
+	}
+}
+
+func TestInstrumentation(t *testing.T) {
+	tests := []struct {
+		name         string
+		fault        *configservicetest.Fault
+		wantStatus   int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		{
+			name:         "success after retries",
+			fault:        &configservicetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 2},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "retries exhausted",
+			fault:        &configservicetest.Fault{StatusCode: http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "not retried",
+			fault:        &configservicetest.Fault{StatusCode: http.StatusNotFound},
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := configservicetest.NewServer()
+			defer srv.Close()
+			srv.AddProject("sockshop")
+			if tt.fault != nil {
+				srv.InjectFault(*tt.fault)
+			}
+			instrumentation := &recordingInstrumentation{}
+			client, err := utils.NewClient(
+				utils.WithBaseURL(srv.URL),
+				utils.WithInstrumentation(instrumentation),
+				utils.WithRetryPolicy(&utils.RetryPolicy{
+					MaxAttempts:          3,
+					InitialBackoff:       10 * time.Millisecond,
+					RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+				}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := utils.ContextWithKeptnContext(context.Background(), "a1b2c3")
+			start := time.Now()
+			_, err = client.Projects().GetProjectWithContext(ctx, models.Project{ProjectName: "sockshop"})
+			elapsed := time.Since(start)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+
+			if len(instrumentation.infos) != 1 || len(instrumentation.results) != 1 {
+				t.Fatalf("got %d started and %d finished requests, want one", len(instrumentation.infos), len(instrumentation.results))
+			}
+			info, result := instrumentation.infos[0], instrumentation.results[0]
+			if info.Operation != "GetProject" || info.Method != "GET" || info.URI != srv.URL+"/v1/project/sockshop" || info.KeptnContext != "a1b2c3" {
+				t.Errorf("got request %+v, want GetProject of sockshop with the keptn context", info)
+			}
+			if result.StatusCode != tt.wantStatus || result.Attempts != tt.wantAttempts {
+				t.Errorf("got status %d after %d attempts, want %d after %d", result.StatusCode, result.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if (result.Err != nil) != tt.wantErr {
+				t.Errorf("got error %v in the result, want error %t", result.Err, tt.wantErr)
+			}
+			// the duration covers all attempts including the backoff between them
+			if minDuration := time.Duration(tt.wantAttempts-1) * 10 * time.Millisecond; result.Duration < minDuration || result.Duration > elapsed {
+				t.Errorf("got duration %s, want between %s and %s", result.Duration, minDuration, elapsed)
+			}
+		})
+	}
+}
+
+func TestInstrumentationOfNestedOperations(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.SetResource("sockshop", "", "", "counter", []byte("1"))
+	instrumentation := &recordingInstrumentation{}
+	client, err := utils.NewClient(utils.WithBaseURL(srv.URL), utils.WithInstrumentation(instrumentation))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Resources().ModifyResource(utils.NewProjectScope("sockshop"), "counter", func(old *models.Resource) (*models.Resource, error) {
+		return &models.Resource{ResourceContent: old.ResourceContent + "0"}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(instrumentation.infos) < 2 {
+		t.Fatalf("got %d requests, want the read and the write", len(instrumentation.infos))
+	}
+	for _, info := range instrumentation.infos {
+		if info.Operation != "ModifyResource" {
+			t.Errorf("got operation %s for %s %s, want ModifyResource", info.Operation, info.Method, info.URI)
+		}
+	}
+}
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *recordedSpan) RecordError(err error)                      { s.err = err }
+func (s *recordedSpan) End()                                       { s.ended = true }
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, spanName string) (context.Context, utils.Span) {
+	span := &recordedSpan{name: spanName, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestTracingInstrumentation(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	tracer := &recordingTracer{}
+	client, err := utils.NewClient(utils.WithBaseURL(srv.URL), utils.WithInstrumentation(utils.NewTracingInstrumentation(tracer)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := utils.ContextWithKeptnContext(context.Background(), "a1b2c3")
+	if _, err := client.Projects().GetProjectWithContext(ctx, models.Project{ProjectName: "sockshop"}); !utils.IsNotFoundError(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("got %d spans, want one", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "configservice GetProject" || !span.ended || span.err == nil {
+		t.Errorf("got span %s (ended %t, error %v), want an ended, failed span of GetProject", span.name, span.ended, span.err)
+	}
+	wantAttributes := map[string]interface{}{
+		utils.SpanAttributeHTTPMethod:     "GET",
+		utils.SpanAttributeHTTPURL:        srv.URL + "/v1/project/sockshop",
+		utils.SpanAttributeHTTPStatusCode: http.StatusNotFound,
+		utils.SpanAttributeKeptnContext:   "a1b2c3",
+		utils.SpanAttributeAttempts:       1,
+	}
+	for key, want := range wantAttributes {
+		if span.attributes[key] != want {
+			t.Errorf("got attribute %s = %v, want %v", key, span.attributes[key], want)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client bundles the handlers for the configuration service, which share
+// the same endpoint, authentication and HTTP client
+type Client struct {
+	baseURL         string
+	scheme          string
+	authHeader      string
+	authToken       string
+	httpClient      *http.Client
+	retryPolicy     *RetryPolicy
+	instrumentation Instrumentation
+	authenticator   Authenticator
+	tlsOptions      *TLSOptions
+	timeout         time.Duration
+	userAgent       string
+	logger          LoggerInterface
+
+	projects  *ProjectHandler
+	stages    *StageHandler
+	services  *ServiceHandler
+	resources *ResourceHandler
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client) error
+
+// WithBaseURL sets the URL of the configuration service. The URL may contain a path prefix,
+// e.g. https://api.keptn.example.com/configuration-service. If the URL does not contain
+// a scheme, http is used.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		if !strings.Contains(baseURL, "://") {
+			baseURL = "http://" + baseURL
+		}
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("Invalid base URL %s: %s", baseURL, err.Error())
+		}
+		if u.Host == "" {
+			return fmt.Errorf("Invalid base URL %s: missing host", baseURL)
+		}
+		c.scheme = u.Scheme
+		c.baseURL = u.Host + strings.TrimSuffix(u.Path, "/")
+		return nil
+	}
+}
+
+// WithScheme overrides the scheme used for connecting to the configuration service
+func WithScheme(scheme string) ClientOption {
+	return func(c *Client) error {
+		c.scheme = scheme
+		return nil
+	}
+}
+
+// WithAuth authenticates all requests by setting the token in the provided header
+func WithAuth(authHeader string, authToken string) ClientOption {
+	return func(c *Client) error {
+		c.authHeader = authHeader
+		c.authToken = authToken
+		return nil
+	}
+}
+
+// WithAuthenticator authenticates all requests with the provided Authenticator, e.g. a
+// TokenFileAuthenticator or an OAuth2ClientCredentialsAuthenticator. It takes precedence over WithAuth.
+func WithAuthenticator(authenticator Authenticator) ClientOption {
+	return func(c *Client) error {
+		c.authenticator = authenticator
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for sending requests
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("HTTP client must not be nil")
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTLS sets the TLS options used for connecting to the configuration service
+func WithTLS(opts TLSOptions) ClientOption {
+	return func(c *Client) error {
+		c.tlsOptions = &opts
+		return nil
+	}
+}
+
+// WithTimeout limits the time until the response headers of a single request arrive. Reading the response body
+// and streaming uploads are not limited, use the context of the request for limiting them.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return errors.New("Timeout must not be negative")
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithLogger logs all requests sent to the configuration service as debug messages
+func WithLogger(logger LoggerInterface) ClientOption {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithInstrumentation reports all requests to the instrumentation, e.g. for recording metrics or creating spans.
+// Use NewMultiInstrumentation for combining several instrumentations.
+func WithInstrumentation(instrumentation Instrumentation) ClientOption {
+	return func(c *Client) error {
+		c.instrumentation = instrumentation
+		return nil
+	}
+}
+
+// NewClient returns a new Client configured by the provided options
+func NewClient(opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		scheme: "http",
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.baseURL == "" {
+		return nil, errors.New("Base URL of the configuration service is missing")
+	}
+
+	httpClient := &http.Client{}
+	if c.httpClient != nil {
+		*httpClient = *c.httpClient
+	}
+	if c.tlsOptions != nil {
+		var err error
+		httpClient, err = newTLSHTTPClient(httpClient, *c.tlsOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.timeout > 0 || c.userAgent != "" || c.logger != nil {
+		httpClient.Transport = &clientTransport{
+			next:      httpClient.Transport,
+			timeout:   c.timeout,
+			userAgent: c.userAgent,
+			logger:    c.logger,
+		}
+	}
+	c.httpClient = httpClient
+	c.init()
+	return c, nil
+}
+
+// newClientFromHandlerArgs returns a Client for the arguments of the NewAuthenticated*Handler constructors
+func newClientFromHandlerArgs(baseURL string, authToken string, authHeader string, httpClient *http.Client, scheme string) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	baseURL = strings.TrimPrefix(baseURL, "http://")
+	baseURL = strings.TrimPrefix(baseURL, "https://")
+	c := &Client{
+		baseURL:    baseURL,
+		scheme:     scheme,
+		authHeader: authHeader,
+		authToken:  authToken,
+		httpClient: httpClient,
+	}
+	c.init()
+	return c
+}
+
+func (c *Client) init() {
+	c.projects = &ProjectHandler{
+		BaseURL:         c.baseURL,
+		AuthHeader:      c.authHeader,
+		AuthToken:       c.authToken,
+		HTTPClient:      c.httpClient,
+		Scheme:          c.scheme,
+		RetryPolicy:     c.retryPolicy,
+		Instrumentation: c.instrumentation,
+		Authenticator:   c.authenticator,
+	}
+	c.stages = &StageHandler{
+		BaseURL:         c.baseURL,
+		AuthHeader:      c.authHeader,
+		AuthToken:       c.authToken,
+		HTTPClient:      c.httpClient,
+		Scheme:          c.scheme,
+		RetryPolicy:     c.retryPolicy,
+		Instrumentation: c.instrumentation,
+		Authenticator:   c.authenticator,
+	}
+	c.services = &ServiceHandler{
+		BaseURL:         c.baseURL,
+		AuthHeader:      c.authHeader,
+		AuthToken:       c.authToken,
+		HTTPClient:      c.httpClient,
+		Scheme:          c.scheme,
+		RetryPolicy:     c.retryPolicy,
+		Instrumentation: c.instrumentation,
+		Authenticator:   c.authenticator,
+	}
+	c.resources = &ResourceHandler{
+		BaseURL:         c.baseURL,
+		AuthHeader:      c.authHeader,
+		AuthToken:       c.authToken,
+		HTTPClient:      c.httpClient,
+		Scheme:          c.scheme,
+		RetryPolicy:     c.retryPolicy,
+		Instrumentation: c.instrumentation,
+		Authenticator:   c.authenticator,
+	}
+}
+
+// Projects returns the handler for projects
+func (c *Client) Projects() *ProjectHandler {
+	return c.projects
+}
+
+// Stages returns the handler for stages
+func (c *Client) Stages() *StageHandler {
+	return c.stages
+}
+
+// Services returns the handler for services
+func (c *Client) Services() *ServiceHandler {
+	return c.services
+}
+
+// Resources returns the handler for resources
+func (c *Client) Resources() *ResourceHandler {
+	return c.resources
+}
+
+// KeptnHandler returns a KeptnHandler using the resource and stage handlers of the client
+func (c *Client) KeptnHandler() *KeptnHandler {
+	return &KeptnHandler{
+		ResourceHandler: c.resources,
+		StageHandler:    c.stages,
+	}
+}
+
+// clientTransport sets the User-Agent header, limits the time until the response headers arrive and
+// logs requests before passing them on
+type clientTransport struct {
+	next      http.RoundTripper
+	timeout   time.Duration
+	userAgent string
+	logger    LoggerInterface
+}
+
+func (t *clientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	start := time.Now()
+	var resp *http.Response
+	var err error
+	if t.timeout > 0 {
+		resp, err = t.roundTripWithTimeout(next, req)
+	} else {
+		resp, err = next.RoundTrip(req)
+	}
+	if t.logger != nil {
+		if err != nil {
+			t.logger.Debug(fmt.Sprintf("%s %s failed after %s: %s", req.Method, req.URL.String(), time.Since(start), err.Error()))
+		} else {
+			t.logger.Debug(fmt.Sprintf("%s %s returned %d after %s", req.Method, req.URL.String(), resp.StatusCode, time.Since(start)))
+		}
+	}
+	return resp, err
+}
+
+// roundTripWithTimeout cancels the request if the response headers do not arrive within the timeout.
+// Once they have arrived, the request is only canceled when the response body is closed.
+func (t *clientTransport) roundTripWithTimeout(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(t.timeout, cancel)
+	resp, err := next.RoundTrip(req.WithContext(ctx))
+	if !timer.Stop() {
+		if err == nil {
+			resp.Body.Close()
+		}
+		cancel()
+		return nil, fmt.Errorf("No response headers received within %s", t.timeout)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the context of a request when its response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,204 @@
+package utils
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Info(message string)  {}
+func (l *recordingLogger) Error(message string) {}
+func (l *recordingLogger) Debug(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, message)
+}
+
+func TestNewClient(t *testing.T) {
+	var gotPath, gotUserAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"projectName":"sockshop"}`))
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	tests := []struct {
+		name          string
+		opts          []ClientOption
+		wantPath      string
+		wantUserAgent string
+	}{
+		{
+			name:     "base URL",
+			opts:     []ClientOption{WithBaseURL(srv.URL)},
+			wantPath: "/v1/project/sockshop",
+		},
+		{
+			name:     "base URL with path prefix",
+			opts:     []ClientOption{WithBaseURL(srv.URL + "/api/configuration-service/")},
+			wantPath: "/api/configuration-service/v1/project/sockshop",
+		},
+		{
+			name:     "base URL without scheme",
+			opts:     []ClientOption{WithBaseURL(host)},
+			wantPath: "/v1/project/sockshop",
+		},
+		{
+			name:     "scheme override",
+			opts:     []ClientOption{WithBaseURL("https://" + host), WithScheme("http")},
+			wantPath: "/v1/project/sockshop",
+		},
+		{
+			name:          "user agent",
+			opts:          []ClientOption{WithBaseURL(srv.URL), WithUserAgent("keptn-service/1.0"), WithTimeout(5 * time.Second)},
+			wantPath:      "/v1/project/sockshop",
+			wantUserAgent: "keptn-service/1.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, gotUserAgent = "", ""
+			c, err := NewClient(tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			project, err := c.Projects().GetProject(models.Project{ProjectName: "sockshop"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if project.ProjectName != "sockshop" {
+				t.Errorf("got project %s, want sockshop", project.ProjectName)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("got path %s, want %s", gotPath, tt.wantPath)
+			}
+			if tt.wantUserAgent != "" && gotUserAgent != tt.wantUserAgent {
+				t.Errorf("got User-Agent %q, want %q", gotUserAgent, tt.wantUserAgent)
+			}
+		})
+	}
+}
+
+func TestNewClientLogsRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	logger := &recordingLogger{}
+	c, err := NewClient(WithBaseURL(srv.URL), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Projects().GetProject(models.Project{ProjectName: "sockshop"}); !IsNotFoundError(err) {
+		t.Errorf("got error %v, want a not found error", err)
+	}
+	if len(logger.messages) != 1 || !strings.HasPrefix(logger.messages[0], "GET "+srv.URL+"/v1/project/sockshop returned 404") {
+		t.Errorf("got log messages %v, want the request", logger.messages)
+	}
+}
+
+func TestNewClientErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+	}{
+		{name: "missing base URL", opts: []ClientOption{WithScheme("https")}},
+		{name: "invalid base URL", opts: []ClientOption{WithBaseURL("http://configuration service:8080")}},
+		{name: "base URL without host", opts: []ClientOption{WithBaseURL("http:///v1")}},
+		{name: "nil HTTP client", opts: []ClientOption{WithBaseURL("configuration-service:8080"), WithHTTPClient(nil)}},
+		{name: "negative timeout", opts: []ClientOption{WithBaseURL("configuration-service:8080"), WithTimeout(-time.Second)}},
+		{name: "invalid TLS options", opts: []ClientOption{WithBaseURL("configuration-service:8080"), WithTLS(TLSOptions{CACertPEM: []byte("no certificate")})}},
+		{
+			name: "TLS options for a custom transport",
+			opts: []ClientOption{
+				WithBaseURL("configuration-service:8080"),
+				WithHTTPClient(&http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}),
+				WithTLS(TLSOptions{InsecureSkipVerify: true}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := NewClient(tt.opts...); err == nil {
+				t.Errorf("got client for %s, want an error", c.baseURL)
+			}
+		})
+	}
+}
+
+func TestNewClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow-headers" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte("first chunk,"))
+		w.(http.Flusher).Flush()
+		// the body takes longer than the timeout, which must not abort the download
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("second chunk"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(WithBaseURL(srv.URL), WithTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	httpClient := c.Resources().HTTPClient
+
+	if resp, err := httpClient.Get(srv.URL + "/slow-headers"); err == nil {
+		resp.Body.Close()
+		t.Error("got response for late headers, want a timeout")
+	}
+
+	resp, err := httpClient.Get(srv.URL + "/slow-body")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error when reading the body: %v", err)
+	}
+	if string(body) != "first chunk,second chunk" {
+		t.Errorf("got body %q, want the complete body", body)
+	}
+}
+
+func TestClientConfigureTLS(t *testing.T) {
+	var gotUserAgent string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"projectName":"sockshop"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(WithBaseURL(srv.URL), WithUserAgent("keptn-service/1.0"), WithLogger(&recordingLogger{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := c.Projects().ConfigureTLS(TLSOptions{CACertPEM: caPEM}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Projects().GetProject(models.Project{ProjectName: "sockshop"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotUserAgent != "keptn-service/1.0" {
+		t.Errorf("got User-Agent %q, want the user agent of the client", gotUserAgent)
+	}
+}
@@ -1,33 +1,116 @@
 package utils
 
 import (
+	"context"
+	"errors"
+
 	"github.com/keptn/go-utils/pkg/models"
-	"gopkg.in/yaml.v2"
 )
 
+const shipyardURI = "shipyard.yaml"
+
 // KeptnHandler provides an interface to keptn resources
 type KeptnHandler struct {
-	ResourceHandler *ResourceHandler
+	ResourceHandler ResourceAPI
+	// StageHandler is used for provisioning the stages of a shipyard. It is only required by ApplyShipyard.
+	StageHandler StageAPI
 }
 
 // NewKeptnHandler returns a new KeptnHandler instance
-func NewKeptnHandler(rh *ResourceHandler) *KeptnHandler {
+func NewKeptnHandler(rh ResourceAPI) *KeptnHandler {
 	return &KeptnHandler{
 		ResourceHandler: rh,
 	}
 }
 
-// GetShipyard returns the shipyard definition of a project
+// GetShipyard returns the shipyard definition of a project. Shipyards of older versions are
+// converted to the current model. The shipyard is not validated, use Validate of the shipyard for checking it.
 func (k *KeptnHandler) GetShipyard(project string) (*models.Shipyard, error) {
-	shipyardResource, err := k.ResourceHandler.GetProjectResource(project, "shipyard.yaml")
+	return k.GetShipyardWithContext(context.Background(), project)
+}
+
+// GetShipyardWithContext returns the shipyard definition of a project
+func (k *KeptnHandler) GetShipyardWithContext(ctx context.Context, project string) (*models.Shipyard, error) {
+	shipyardResource, err := k.ResourceHandler.GetProjectResourceWithContext(ctx, project, shipyardURI)
 	if err != nil {
 		return nil, err
 	}
 
-	shipyard := models.Shipyard{}
-	err = yaml.Unmarshal([]byte(shipyardResource.ResourceContent), &shipyard)
+	shipyard, err := models.ParseShipyard([]byte(shipyardResource.ResourceContent))
 	if err != nil {
 		return nil, err
 	}
-	return &shipyard, nil
+	return shipyard, nil
+}
+
+// UpdateShipyard stores the shipyard as shipyard of the project. The shipyard is written
+// in the version of the stored shipyard, so consumers of the project can still read it. It returns the new
+// version of the shipyard resource.
+func (k *KeptnHandler) UpdateShipyard(project string, shipyard *models.Shipyard) (string, error) {
+	return k.UpdateShipyardWithContext(context.Background(), project, shipyard)
+}
+
+// UpdateShipyardWithContext stores the shipyard as shipyard of the project
+func (k *KeptnHandler) UpdateShipyardWithContext(ctx context.Context, project string, shipyard *models.Shipyard) (string, error) {
+	apiVersion := models.ShipyardAPIVersionV1Alpha1
+	stored, err := k.ResourceHandler.GetProjectResourceWithContext(ctx, project, shipyardURI)
+	if err == nil {
+		if apiVersion, err = models.GetShipyardAPIVersion([]byte(stored.ResourceContent)); err != nil {
+			return "", err
+		}
+	} else if !IsNotFoundError(err) {
+		return "", err
+	}
+
+	content, err := models.MarshalShipyard(shipyard, apiVersion)
+	if err != nil {
+		return "", err
+	}
+	uri := shipyardURI
+	return k.ResourceHandler.UpdateProjectResourceWithContext(ctx, project, &models.Resource{
+		ResourceURI:     &uri,
+		ResourceContent: string(content),
+	})
+}
+
+// ApplyShipyard creates all stages of the shipyard of a project which do not exist yet. It returns
+// the names of the created stages.
+func (k *KeptnHandler) ApplyShipyard(project string) ([]string, error) {
+	return k.ApplyShipyardWithContext(context.Background(), project)
+}
+
+// ApplyShipyardWithContext creates all stages of the shipyard of a project which do not exist yet
+func (k *KeptnHandler) ApplyShipyardWithContext(ctx context.Context, project string) ([]string, error) {
+	if k.StageHandler == nil {
+		return nil, errors.New("KeptnHandler has no StageHandler for creating stages")
+	}
+	shipyard, err := k.GetShipyardWithContext(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := k.StageHandler.GetAllStagesWithContext(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]bool{}
+	for _, stage := range stages {
+		existing[stage.StageName] = true
+	}
+
+	created := []string{}
+	for _, stage := range shipyard.Stages {
+		if existing[stage.Name] {
+			continue
+		}
+		if err := k.StageHandler.CreateStageWithContext(ctx, project, stage.Name); err != nil {
+			if IsConflictError(err) {
+				// the stage has been created concurrently
+				continue
+			}
+			return created, err
+		}
+		created = append(created, stage.Name)
+	}
+	return created, nil
 }
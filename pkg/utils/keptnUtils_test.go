@@ -0,0 +1,79 @@
+package utils_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+	"github.com/keptn/go-utils/pkg/utils/mocks"
+)
+
+func TestApplyShipyard(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.SetResource("sockshop", "", "", "shipyard.yaml", []byte(`stages:
+- name: dev
+  deployment_strategy: direct
+- name: staging
+  deployment_strategy: direct
+- name: prod
+  deployment_strategy: blue_green_service
+`))
+
+	stageHandler := &mocks.StageAPIMock{
+		GetAllStagesWithContextFunc: func(ctx context.Context, project string) ([]*models.Stage, error) {
+			return []*models.Stage{{StageName: "dev"}}, nil
+		},
+		CreateStageWithContextFunc: func(ctx context.Context, project string, stageName string) error {
+			if stageName == "staging" {
+				// the stage has been created by someone else in the meantime
+				return &utils.APIError{StatusCode: http.StatusConflict}
+			}
+			return nil
+		},
+	}
+	k := utils.NewKeptnHandler(utils.NewResourceHandler(srv.URL))
+	k.StageHandler = stageHandler
+
+	created, err := k.ApplyShipyard("sockshop")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(created, ",") != "prod" {
+		t.Errorf("got created stages %v, want [prod]", created)
+	}
+	if calls := len(stageHandler.CreateStageWithContextCalls()); calls != 2 {
+		t.Errorf("got %d calls for creating a stage, want 2", calls)
+	}
+}
+
+func TestGetShipyardDoesNotValidate(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	// shipyards without or with an unknown deployment strategy have been loadable before validation existed
+	srv.SetResource("sockshop", "", "", "shipyard.yaml", []byte(`stages:
+- name: dev
+- name: prod
+  deployment_strategy: canary
+`))
+	k := utils.NewKeptnHandler(utils.NewResourceHandler(srv.URL))
+
+	shipyard, err := k.GetShipyard("sockshop")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(shipyard.Stages) != 2 || shipyard.Stages[1].DeploymentStrategy != "canary" {
+		t.Errorf("got stages %+v, want dev and prod", shipyard.Stages)
+	}
+	if err := shipyard.Validate(); err == nil {
+		t.Error("got no validation error, want the deployment strategies to be rejected")
+	}
+
+	if _, err := k.UpdateShipyard("sockshop", shipyard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -1,12 +1,14 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/util/retry"
 
@@ -39,16 +41,37 @@ func DoHelmUpgrade(project string, stage string) error {
 
 // RestartPodsWithSelector restarts the pods which are found in the provided namespace and selector
 func RestartPodsWithSelector(useInClusterConfig bool, namespace string, selector string) error {
-	clientset, err := GetKubeAPI(useInClusterConfig)
+	return RestartPodsWithSelectorWithContext(context.Background(), useInClusterConfig, namespace, selector)
+}
+
+// RestartPodsWithSelectorWithContext restarts the pods which are found in the provided namespace and selector.
+// The requests to the Kubernetes API are cancelled when the context is done.
+func RestartPodsWithSelectorWithContext(ctx context.Context, useInClusterConfig bool, namespace string, selector string) error {
+	clientset, err := GetClientset(useInClusterConfig)
 	if err != nil {
 		return err
 	}
-	pods, err := clientset.Pods(namespace).List(metav1.ListOptions{LabelSelector: selector})
+	pods := &typesv1.PodList{}
+	err = clientset.CoreV1().RESTClient().Get().
+		Context(ctx).
+		Namespace(namespace).
+		Resource("pods").
+		VersionedParams(&metav1.ListOptions{LabelSelector: selector}, scheme.ParameterCodec).
+		Do().
+		Into(pods)
 	if err != nil {
 		return err
 	}
 	for _, pod := range pods.Items {
-		if err := clientset.Pods(namespace).Delete(pod.Name, &metav1.DeleteOptions{}); err != nil {
+		err := clientset.CoreV1().RESTClient().Delete().
+			Context(ctx).
+			Namespace(namespace).
+			Resource("pods").
+			Name(pod.Name).
+			Body(&metav1.DeleteOptions{}).
+			Do().
+			Error()
+		if err != nil {
 			return err
 		}
 	}
@@ -81,27 +104,74 @@ func WaitForPodsWithSelector(useInClusterConfig bool, namespace string, selector
 }
 
 func ScaleDeployment(useInClusterConfig bool, deployment string, namespace string, replicas int32) error {
+	return ScaleDeploymentWithContext(context.Background(), useInClusterConfig, deployment, namespace, replicas)
+}
+
+// ScaleDeploymentWithContext sets the replicas of a deployment. The requests to the Kubernetes API are
+// cancelled when the context is done.
+func ScaleDeploymentWithContext(ctx context.Context, useInClusterConfig bool, deployment string, namespace string, replicas int32) error {
 	clientset, err := GetClientset(useInClusterConfig)
 	if err != nil {
 		return err
 	}
-	deploymentsClient := clientset.AppsV1().Deployments(namespace)
 
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Retrieve the latest version of Deployment before attempting update
 		// RetryOnConflict uses exponential backoff to avoid exhausting the apiserver
-		result, getErr := deploymentsClient.Get(deployment, metav1.GetOptions{})
+		result, getErr := readDeployment(ctx, clientset, namespace, deployment)
 		if getErr != nil {
 			return fmt.Errorf("Failed to get latest version of Deployment: %v", getErr)
 		}
 
 		result.Spec.Replicas = int32Ptr(replicas)
-		_, updateErr := deploymentsClient.Update(result)
-		return updateErr
+		return clientset.AppsV1().RESTClient().Put().
+			Context(ctx).
+			Namespace(namespace).
+			Resource("deployments").
+			Name(deployment).
+			Body(result).
+			Do().
+			Error()
 	})
 	return retryErr
 }
 
+// GetDeploymentReplicas returns the desired number of replicas of a deployment
+func GetDeploymentReplicas(useInClusterConfig bool, deployment string, namespace string) (int32, error) {
+	return GetDeploymentReplicasWithContext(context.Background(), useInClusterConfig, deployment, namespace)
+}
+
+// GetDeploymentReplicasWithContext returns the desired number of replicas of a deployment. The request to the
+// Kubernetes API is cancelled when the context is done.
+func GetDeploymentReplicasWithContext(ctx context.Context, useInClusterConfig bool, deployment string, namespace string) (int32, error) {
+	clientset, err := GetClientset(useInClusterConfig)
+	if err != nil {
+		return 0, err
+	}
+	dep, err := readDeployment(ctx, clientset, namespace, deployment)
+	if err != nil {
+		return 0, err
+	}
+	if dep.Spec.Replicas == nil {
+		return 1, nil
+	}
+	return *dep.Spec.Replicas, nil
+}
+
+// readDeployment gets a deployment with a request which is cancelled when the context is done
+func readDeployment(ctx context.Context, clientset *kubernetes.Clientset, namespace string, deployment string) (*appsv1.Deployment, error) {
+	result := &appsv1.Deployment{}
+	err := clientset.AppsV1().RESTClient().Get().
+		Context(ctx).
+		Namespace(namespace).
+		Resource("deployments").
+		Name(deployment).
+		VersionedParams(&metav1.GetOptions{}, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
 func int32Ptr(i int32) *int32 { return &i }
 
 // WaitForDeploymentToBeRolledOut waits until the deployment is Available
@@ -0,0 +1,264 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+// Iterator iterates over the entries of a list endpoint of the configuration service
+type Iterator interface {
+	// Next advances the iterator and reports whether another entry is available
+	Next() bool
+	// Err returns the error which stopped the iteration, if any
+	Err() error
+	// TotalCount returns the total number of entries as reported by the configuration service
+	TotalCount() float64
+}
+
+// ProjectIterator iterates over projects
+type ProjectIterator interface {
+	Iterator
+	// Project returns the current project
+	Project() *models.Project
+}
+
+// StageIterator iterates over stages
+type StageIterator interface {
+	Iterator
+	// Stage returns the current stage
+	Stage() *models.Stage
+}
+
+// ServiceIterator iterates over services
+type ServiceIterator interface {
+	Iterator
+	// Service returns the current service
+	Service() *models.Service
+}
+
+// ResourceIterator iterates over resources
+type ResourceIterator interface {
+	Iterator
+	// Resource returns the current resource
+	Resource() *models.Resource
+	// VersionedResource returns the current resource together with its version
+	VersionedResource() *models.VersionedResource
+}
+
+// VersionIterator iterates over the version history of a resource
+type VersionIterator interface {
+	Iterator
+	// Version returns the current version
+	Version() *models.Version
+}
+
+// pager fetches the pages of a list endpoint of the configuration service one after another
+type pager struct {
+	ctx         context.Context
+	c           ConfigService
+	uri         string
+	pageSize    int
+	nextPageKey string
+	done        bool
+	totalCount  float64
+	err         error
+}
+
+func newPager(ctx context.Context, c ConfigService, uri string, pageSize int) pager {
+	return pager{
+		ctx:      ctx,
+		c:        c,
+		uri:      uri,
+		pageSize: pageSize,
+	}
+}
+
+// fetch loads the next page into out and reports whether a page has been loaded.
+// pageInfo returns the nextPageKey and totalCount of the loaded page.
+func (p *pager) fetch(out interface{}, pageInfo func() (string, float64)) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	u, err := url.Parse(p.uri)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	q := u.Query()
+	if p.pageSize > 0 {
+		q.Set("pageSize", strconv.Itoa(p.pageSize))
+	}
+	if p.nextPageKey != "" {
+		q.Set("nextPageKey", p.nextPageKey)
+	}
+	u.RawQuery = q.Encode()
+
+	if err := get(p.ctx, u.String(), p.c, out); err != nil {
+		p.err = err
+		return false
+	}
+
+	nextPageKey, totalCount := pageInfo()
+	p.totalCount = totalCount
+	if nextPageKey == "" || nextPageKey == "0" {
+		p.done = true
+	} else if nextPageKey == p.nextPageKey {
+		p.err = errors.New("configuration service returned the same nextPageKey twice")
+		return false
+	}
+	p.nextPageKey = nextPageKey
+	return true
+}
+
+// Err returns the error which stopped the iteration, if any
+func (p *pager) Err() error {
+	return p.err
+}
+
+// TotalCount returns the total number of entries as reported by the last fetched page
+func (p *pager) TotalCount() float64 {
+	return p.totalCount
+}
+
+// projectIterator iterates over projects and fetches the pages lazily
+type projectIterator struct {
+	pager
+	page    []*models.Project
+	current *models.Project
+}
+
+// Next advances the iterator and reports whether another project is available
+func (it *projectIterator) Next() bool {
+	for len(it.page) == 0 {
+		var received models.Projects
+		if !it.fetch(&received, func() (string, float64) { return received.NextPageKey, received.TotalCount }) {
+			return false
+		}
+		it.page = received.Projects
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Project returns the current project
+func (it *projectIterator) Project() *models.Project {
+	return it.current
+}
+
+// stageIterator iterates over stages and fetches the pages lazily
+type stageIterator struct {
+	pager
+	page    []*models.Stage
+	current *models.Stage
+}
+
+// Next advances the iterator and reports whether another stage is available
+func (it *stageIterator) Next() bool {
+	for len(it.page) == 0 {
+		var received models.Stages
+		if !it.fetch(&received, func() (string, float64) { return received.NextPageKey, received.TotalCount }) {
+			return false
+		}
+		it.page = received.Stages
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Stage returns the current stage
+func (it *stageIterator) Stage() *models.Stage {
+	return it.current
+}
+
+// serviceIterator iterates over services and fetches the pages lazily
+type serviceIterator struct {
+	pager
+	page    []*models.Service
+	current *models.Service
+}
+
+// Next advances the iterator and reports whether another service is available
+func (it *serviceIterator) Next() bool {
+	for len(it.page) == 0 {
+		var received models.Services
+		if !it.fetch(&received, func() (string, float64) { return received.NextPageKey, received.TotalCount }) {
+			return false
+		}
+		it.page = received.Services
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Service returns the current service
+func (it *serviceIterator) Service() *models.Service {
+	return it.current
+}
+
+// resourceIterator iterates over resources and fetches the pages lazily
+type resourceIterator struct {
+	pager
+	page        []*models.VersionedResource
+	current     *models.VersionedResource
+	dropContent bool
+}
+
+// Next advances the iterator and reports whether another resource is available
+func (it *resourceIterator) Next() bool {
+	for len(it.page) == 0 {
+		var received models.VersionedResources
+		if !it.fetch(&received, func() (string, float64) { return received.NextPageKey, received.TotalCount }) {
+			return false
+		}
+		it.page = received.Resources
+		if it.dropContent {
+			for _, resource := range it.page {
+				if resource != nil {
+					resource.ResourceContent = ""
+				}
+			}
+		}
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Resource returns the current resource
+func (it *resourceIterator) Resource() *models.Resource {
+	return &it.current.Resource
+}
+
+// VersionedResource returns the current resource together with its version
+func (it *resourceIterator) VersionedResource() *models.VersionedResource {
+	return it.current
+}
+
+// versionIterator iterates over versions and fetches the pages lazily
+type versionIterator struct {
+	pager
+	page    []*models.Version
+	current *models.Version
+}
+
+// Next advances the iterator and reports whether another version is available
+func (it *versionIterator) Next() bool {
+	for len(it.page) == 0 {
+		var received models.Versions
+		if !it.fetch(&received, func() (string, float64) { return received.NextPageKey, received.TotalCount }) {
+			return false
+		}
+		it.page = received.Versions
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Version returns the current version
+func (it *versionIterator) Version() *models.Version {
+	return it.current
+}
@@ -0,0 +1,123 @@
+package utils_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+)
+
+func TestIterateProjects(t *testing.T) {
+	tests := []struct {
+		name         string
+		projects     int
+		pageSize     int
+		wantRequests int
+	}{
+		{name: "no projects", projects: 0, pageSize: 2, wantRequests: 1},
+		{name: "single page", projects: 2, pageSize: 5, wantRequests: 1},
+		{name: "last page full", projects: 4, pageSize: 2, wantRequests: 2},
+		{name: "last page partial", projects: 5, pageSize: 2, wantRequests: 3},
+		{name: "page size of the server", projects: 5, pageSize: 0, wantRequests: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := configservicetest.NewServer()
+			defer srv.Close()
+			srv.PageSize = 3
+			want := []string{}
+			for i := 0; i < tt.projects; i++ {
+				name := fmt.Sprintf("project-%d", i)
+				srv.AddProject(name)
+				want = append(want, name)
+			}
+
+			it := utils.NewProjectHandler(srv.URL).IterateProjects(context.Background(), tt.pageSize)
+			got := []string{}
+			for it.Next() {
+				got = append(got, it.Project().ProjectName)
+			}
+			if it.Err() != nil {
+				t.Fatalf("unexpected error: %v", it.Err())
+			}
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("got projects %v, want %v", got, want)
+			}
+			if srv.RequestCount() != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", srv.RequestCount(), tt.wantRequests)
+			}
+			if it.TotalCount() != float64(tt.projects) {
+				t.Errorf("got total count %g, want %d", it.TotalCount(), tt.projects)
+			}
+		})
+	}
+}
+
+func TestGetAllStagesFetchesAllPages(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 2
+	for _, stage := range []string{"dev", "staging", "production"} {
+		srv.AddStage("sockshop", stage)
+	}
+
+	stages, err := utils.NewStageHandler(srv.URL).GetAllStages("sockshop")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stages) != 3 || stages[2].StageName != "production" {
+		t.Errorf("got stages %v, want dev, staging and production", stages)
+	}
+}
+
+func TestIterateStopsOnError(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 1
+	srv.AddService("sockshop", "dev", "carts")
+	srv.AddService("sockshop", "dev", "orders")
+	srv.InjectFault(configservicetest.Fault{PathPrefix: "/v1/project/sockshop/stage/dev/service", StatusCode: http.StatusInternalServerError})
+
+	it := utils.NewServiceHandler(srv.URL).IterateServices(context.Background(), "sockshop", "dev", 1)
+	if it.Next() {
+		t.Errorf("got service %v, want none", it.Service())
+	}
+	if it.Err() == nil {
+		t.Error("got no error, want the error of the failed request")
+	}
+}
+
+func TestIterateRejectsRepeatedNextPageKey(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(models.Projects{
+			Projects:    []*models.Project{{ProjectName: fmt.Sprintf("project-%d", requests)}},
+			NextPageKey: "1",
+			TotalCount:  10,
+		})
+	}))
+	defer srv.Close()
+
+	it := utils.NewProjectHandler(srv.URL).IterateProjects(context.Background(), 1)
+	count := 0
+	for it.Next() {
+		count++
+		if count > 10 {
+			t.Fatal("iteration did not stop")
+		}
+	}
+	if it.Err() == nil {
+		t.Error("got no error, want an error for the repeated nextPageKey")
+	}
+	if count != 1 || requests != 2 {
+		t.Errorf("got %d projects in %d requests, want 1 project in 2 requests", count, requests)
+	}
+}
@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	b64 "encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	exportManifestName = "manifest.yaml"
+	exportProjectDir   = "project"
+	exportStagesDir    = "stages"
+	exportResourcesDir = "resources"
+	exportServicesDir  = "services"
+	redactedSecret     = "<redacted>"
+
+	// importCleanupTimeout limits the deletion of a partially imported project
+	importCleanupTimeout = 30 * time.Second
+)
+
+// ProjectExportManifest describes an exported project
+type ProjectExportManifest struct {
+	Project      string                `yaml:"project"`
+	GitRemoteURI string                `yaml:"gitRemoteURI,omitempty"`
+	GitUser      string                `yaml:"gitUser,omitempty"`
+	GitToken     string                `yaml:"gitToken,omitempty"`
+	ExportedAt   time.Time             `yaml:"exportedAt"`
+	Stages       []*ProjectExportStage `yaml:"stages"`
+}
+
+// ProjectExportStage describes an exported stage and its services
+type ProjectExportStage struct {
+	Name     string   `yaml:"name"`
+	Services []string `yaml:"services"`
+}
+
+// ImportOptions configures the import of an exported project
+type ImportOptions struct {
+	// ProjectName overrides the name of the project
+	ProjectName string
+	// GitToken is used for the upstream repository, as the token is not contained in an export
+	GitToken string
+}
+
+// ExportProject writes all project, stage and service resources of a project and a manifest to w as tar.gz.
+// The git token of the project is redacted in the manifest, and credentials contained in the git remote URI are removed.
+func ExportProject(ctx context.Context, projects ProjectAPI, stages StageAPI, services ServiceAPI, resources ResourceAPI, project string, w io.Writer) error {
+	p, err := projects.GetProjectWithContext(ctx, models.Project{ProjectName: project})
+	if err != nil {
+		return err
+	}
+	manifest := &ProjectExportManifest{
+		Project:      p.ProjectName,
+		GitRemoteURI: removeURICredentials(p.GitRemoteURI),
+		GitUser:      p.GitUser,
+		ExportedAt:   time.Now().UTC(),
+		Stages:       []*ProjectExportStage{},
+	}
+	if p.GitToken != "" {
+		manifest.GitToken = redactedSecret
+	}
+
+	allStages, err := stages.GetAllStagesWithContext(ctx, project)
+	if err != nil {
+		return err
+	}
+	for _, stage := range allStages {
+		stageServices, err := services.GetAllServicesWithContext(ctx, project, stage.StageName)
+		if err != nil {
+			return err
+		}
+		exportedStage := &ProjectExportStage{Name: stage.StageName, Services: []string{}}
+		for _, service := range stageServices {
+			exportedStage.Services = append(exportedStage.Services, service.ServiceName)
+		}
+		manifest.Stages = append(manifest.Stages, exportedStage)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	manifestData, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, exportManifestName, manifestData); err != nil {
+		return err
+	}
+
+	if err := exportResources(ctx, resources, tw, NewProjectScope(project), exportProjectDir); err != nil {
+		return err
+	}
+	for _, stage := range manifest.Stages {
+		stageDir := path.Join(exportStagesDir, stage.Name)
+		if err := exportResources(ctx, resources, tw, NewStageScope(project, stage.Name), path.Join(stageDir, exportResourcesDir)); err != nil {
+			return err
+		}
+		for _, service := range stage.Services {
+			serviceDir := path.Join(stageDir, exportServicesDir, service)
+			if err := exportResources(ctx, resources, tw, NewServiceScope(project, stage.Name, service), serviceDir); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now()}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// exportResources writes the resources of the scope into dir of the archive. The listing of the
+// configuration service contains the content, so each resource is downloaded only once.
+func exportResources(ctx context.Context, resources ResourceAPI, tw *tar.Writer, scope ResourceScope, dir string) error {
+	it := resources.IterateResources(ctx, scope, 0)
+	for it.Next() {
+		resource := it.Resource()
+		if resource.ResourceURI == nil {
+			continue
+		}
+		content, err := b64.StdEncoding.DecodeString(resource.ResourceContent)
+		if err == nil {
+			err = writeTarFile(tw, path.Join(dir, *resource.ResourceURI), content)
+		}
+		if err != nil {
+			return fmt.Errorf("Error when exporting resource %s of %s: %s", *resource.ResourceURI, scope, err.Error())
+		}
+	}
+	return it.Err()
+}
+
+// removeURICredentials removes the user info, which may contain a token, from a URI
+func removeURICredentials(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.User == nil {
+		return uri
+	}
+	u.User = nil
+	return u.String()
+}
+
+// ImportProject recreates a project exported by ExportProject, including its stages, services and resources.
+// If the import fails after the project has been created, the project is deleted again.
+func ImportProject(ctx context.Context, projects ProjectAPI, stages StageAPI, services ServiceAPI, resources ResourceAPI, r io.Reader, opts ImportOptions) (*ProjectExportManifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	header, err := tr.Next()
+	if err != nil {
+		return nil, err
+	}
+	if header.Name != exportManifestName {
+		return nil, fmt.Errorf("Invalid project export: expected %s as first entry, found %s", exportManifestName, header.Name)
+	}
+	manifestData, err := ioutil.ReadAll(tr)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ProjectExportManifest{}
+	if err := yaml.Unmarshal(manifestData, manifest); err != nil {
+		return nil, err
+	}
+
+	project := manifest.Project
+	if opts.ProjectName != "" {
+		project = opts.ProjectName
+	}
+	gitToken := opts.GitToken
+	if gitToken == "" && manifest.GitToken != redactedSecret {
+		gitToken = manifest.GitToken
+	}
+	if err := projects.CreateProjectWithContext(ctx, models.Project{
+		ProjectName:  project,
+		GitRemoteURI: manifest.GitRemoteURI,
+		GitUser:      manifest.GitUser,
+		GitToken:     gitToken,
+	}); err != nil {
+		return nil, err
+	}
+	if err := importProjectContent(ctx, stages, services, resources, tr, project, manifest); err != nil {
+		// ctx may already be done, so the deletion gets its own deadline
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), importCleanupTimeout)
+		defer cancel()
+		if deleteErr := projects.DeleteProjectWithContext(cleanupCtx, models.Project{ProjectName: project}); deleteErr != nil {
+			return nil, fmt.Errorf("%s; the partially imported project %s could not be deleted: %s", err.Error(), project, deleteErr.Error())
+		}
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// importProjectContent creates the stages and services of the manifest and imports the resources of the archive
+func importProjectContent(ctx context.Context, stages StageAPI, services ServiceAPI, resources ResourceAPI, tr *tar.Reader, project string, manifest *ProjectExportManifest) error {
+	for _, stage := range manifest.Stages {
+		if err := stages.CreateStageWithContext(ctx, project, stage.Name); err != nil && !IsConflictError(err) {
+			return err
+		}
+		for _, service := range stage.Services {
+			if err := services.CreateServiceWithContext(ctx, project, stage.Name, service); err != nil && !IsConflictError(err) {
+				return err
+			}
+		}
+	}
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		scope, resourceURI, err := parseExportPath(project, header.Name)
+		if err != nil {
+			return err
+		}
+		if _, err := resources.WriteResourceContent(ctx, scope, resourceURI, tr); err != nil {
+			return fmt.Errorf("Error when importing resource %s of %s: %s", resourceURI, scope, err.Error())
+		}
+	}
+}
+
+// parseExportPath returns the scope and resource URI of an archive entry
+func parseExportPath(project string, name string) (ResourceScope, string, error) {
+	parts := strings.Split(path.Clean(name), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == exportProjectDir:
+		return NewProjectScope(project), path.Join(parts[1:]...), nil
+	case len(parts) >= 4 && parts[0] == exportStagesDir && parts[2] == exportResourcesDir:
+		return NewStageScope(project, parts[1]), path.Join(parts[3:]...), nil
+	case len(parts) >= 5 && parts[0] == exportStagesDir && parts[2] == exportServicesDir:
+		return NewServiceScope(project, parts[1], parts[3]), path.Join(parts[4:]...), nil
+	}
+	return ResourceScope{}, "", errors.New("Invalid project export: unexpected entry " + name)
+}
@@ -1,11 +1,9 @@
 package utils
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
-	"io/ioutil"
 	"net/http"
-	"strings"
 
 	"github.com/keptn/go-utils/pkg/models"
 )
@@ -17,35 +15,22 @@ type ProjectHandler struct {
 	AuthHeader string
 	HTTPClient *http.Client
 	Scheme     string
+	// RetryPolicy configures the retries of failed requests. Requests are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+	// Instrumentation observes all requests, e.g. for recording metrics. It is optional.
+	Instrumentation Instrumentation
+	// Authenticator adds the credentials to all requests. If it is nil, AuthToken is set in AuthHeader.
+	Authenticator Authenticator
 }
 
 // NewProjectHandler returns a new ProjectHandler
 func NewProjectHandler(baseURL string) *ProjectHandler {
-	baseURL = strings.TrimPrefix(baseURL, "http://")
-	baseURL = strings.TrimPrefix(baseURL, "https://")
-	return &ProjectHandler{
-		BaseURL:    baseURL,
-		AuthHeader: "",
-		AuthToken:  "",
-		HTTPClient: &http.Client{},
-		Scheme:     "http",
-	}
+	return NewAuthenticatedProjectHandler(baseURL, "", "", nil, "http")
 }
 
 // NewAuthenticatedProjectHandler returns a new ProjectHandler that authenticates at the endpoint via the provided token
 func NewAuthenticatedProjectHandler(baseURL string, authToken string, authHeader string, httpClient *http.Client, scheme string) *ProjectHandler {
-	if httpClient == nil {
-		httpClient = &http.Client{}
-	}
-	baseURL = strings.TrimPrefix(baseURL, "http://")
-	baseURL = strings.TrimPrefix(baseURL, "https://")
-	return &ProjectHandler{
-		BaseURL:    baseURL,
-		AuthHeader: authHeader,
-		AuthToken:  authToken,
-		HTTPClient: httpClient,
-		Scheme:     scheme,
-	}
+	return newClientFromHandlerArgs(baseURL, authToken, authHeader, httpClient, scheme).Projects()
 }
 
 func (p *ProjectHandler) getBaseURL() string {
@@ -60,67 +45,111 @@ func (p *ProjectHandler) getAuthHeader() string {
 	return p.AuthHeader
 }
 
-func (p *ProjectHandler) getHTTPClient() *http.Client {
-	return p.HTTPClient
+func (p *ProjectHandler) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return p.HTTPClient.Do(req.WithContext(ctx))
+}
+
+func (p *ProjectHandler) getRetryPolicy() *RetryPolicy {
+	return p.RetryPolicy
+}
+
+func (p *ProjectHandler) getInstrumentation() Instrumentation {
+	return p.Instrumentation
+}
+
+func (p *ProjectHandler) getAuthenticator() Authenticator {
+	return p.Authenticator
+}
+
+// ConfigureTLS applies the TLS options to the HTTP client of the ProjectHandler.
+// Other HTTP clients of the process are not affected.
+func (p *ProjectHandler) ConfigureTLS(opts TLSOptions) error {
+	httpClient, err := newTLSHTTPClient(p.HTTPClient, opts)
+	if err != nil {
+		return err
+	}
+	p.HTTPClient = httpClient
+	return nil
 }
 
 // CreateProject creates a new project
-func (p *ProjectHandler) CreateProject(project models.Project) (*models.Error, error) {
+func (p *ProjectHandler) CreateProject(project models.Project) error {
+	return p.CreateProjectWithContext(context.Background(), project)
+}
+
+// CreateProjectWithContext creates a new project
+func (p *ProjectHandler) CreateProjectWithContext(ctx context.Context, project models.Project) error {
+	ctx = withOperation(ctx, "CreateProject")
 	bodyStr, err := json.Marshal(project)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return post(p.Scheme+"://"+p.getBaseURL()+"/v1/project", bodyStr, p)
+	return post(ctx, p.Scheme+"://"+p.getBaseURL()+"/v1/project", bodyStr, p)
 }
 
 // DeleteProject deletes a project
-func (p *ProjectHandler) DeleteProject(project models.Project) (*models.Error, error) {
-	return delete(p.Scheme+"://"+p.getBaseURL()+"/v1/project/"+project.ProjectName, p)
+func (p *ProjectHandler) DeleteProject(project models.Project) error {
+	return p.DeleteProjectWithContext(context.Background(), project)
+}
+
+// DeleteProjectWithContext deletes a project
+func (p *ProjectHandler) DeleteProjectWithContext(ctx context.Context, project models.Project) error {
+	ctx = withOperation(ctx, "DeleteProject")
+	return delete(ctx, p.Scheme+"://"+p.getBaseURL()+"/v1/project/"+project.ProjectName, p)
 }
 
 // GetProject returns a project
-func (p *ProjectHandler) GetProject(project models.Project) (*models.Project, *models.Error) {
-	return get(p.Scheme+"://"+p.getBaseURL()+"/v1/project/"+project.ProjectName, p)
+func (p *ProjectHandler) GetProject(project models.Project) (*models.Project, error) {
+	return p.GetProjectWithContext(context.Background(), project)
 }
 
-func get(uri string, c ConfigService) (*models.Project, *models.Error) {
+// GetProjectWithContext returns a project
+func (p *ProjectHandler) GetProjectWithContext(ctx context.Context, project models.Project) (*models.Project, error) {
+	ctx = withOperation(ctx, "GetProject")
+	var respProject models.Project
+	if err := get(ctx, p.Scheme+"://"+p.getBaseURL()+"/v1/project/"+project.ProjectName, p, &respProject); err != nil {
+		return nil, err
+	}
+	return &respProject, nil
+}
 
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	req, err := http.NewRequest("GET", uri, nil)
-	req.Header.Set("Content-Type", "application/json")
-	addAuthHeader(req, c)
+// UpdateProject updates a project, e.g. its git credentials
+func (p *ProjectHandler) UpdateProject(project models.Project) error {
+	return p.UpdateProjectWithContext(context.Background(), project)
+}
 
-	resp, err := c.getHTTPClient().Do(req)
+// UpdateProjectWithContext updates a project, e.g. its git credentials
+func (p *ProjectHandler) UpdateProjectWithContext(ctx context.Context, project models.Project) error {
+	ctx = withOperation(ctx, "UpdateProject")
+	bodyStr, err := json.Marshal(project)
 	if err != nil {
-		return nil, buildErrorResponse(err.Error())
+		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, buildErrorResponse(err.Error())
-		}
-
-		var respProject models.Project
-		err = json.Unmarshal(body, &respProject)
-		if err != nil {
-			return nil, buildErrorResponse(err.Error())
-		}
+	return put(ctx, p.Scheme+"://"+p.getBaseURL()+"/v1/project/"+project.ProjectName, bodyStr, p)
+}
 
-		return &respProject, nil
-	}
+// GetAllProjects returns a list of all projects.
+func (p *ProjectHandler) GetAllProjects() ([]*models.Project, error) {
+	return p.GetAllProjectsWithContext(context.Background())
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, buildErrorResponse(err.Error())
+// GetAllProjectsWithContext returns a list of all projects.
+func (p *ProjectHandler) GetAllProjectsWithContext(ctx context.Context) ([]*models.Project, error) {
+	ctx = withOperation(ctx, "GetAllProjects")
+	projects := []*models.Project{}
+	it := p.IterateProjects(ctx, 0)
+	for it.Next() {
+		projects = append(projects, it.Project())
 	}
-
-	var respErr models.Error
-	err = json.Unmarshal(body, &respErr)
-	if err != nil {
-		return nil, buildErrorResponse(err.Error())
+	if it.Err() != nil {
+		return nil, it.Err()
 	}
+	return projects, nil
+}
 
-	return nil, &respErr
+// IterateProjects returns an iterator over all projects, which fetches pages of the provided size on demand.
+// If pageSize is 0, the page size of the configuration service is used.
+func (p *ProjectHandler) IterateProjects(ctx context.Context, pageSize int) ProjectIterator {
+	ctx = withOperation(ctx, "IterateProjects")
+	return &projectIterator{pager: newPager(ctx, p, p.Scheme+"://"+p.getBaseURL()+"/v1/project", pageSize)}
 }
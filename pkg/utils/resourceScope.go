@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"net/url"
+)
+
+// ResourceScope identifies the level resources are stored at. Resources belong to a project
+// if Stage is empty, to a stage if Service is empty, and to a service otherwise.
+type ResourceScope struct {
+	Project string
+	Stage   string
+	Service string
+}
+
+// NewProjectScope returns the scope of the project level resources
+func NewProjectScope(project string) ResourceScope {
+	return ResourceScope{Project: project}
+}
+
+// NewStageScope returns the scope of the stage level resources
+func NewStageScope(project string, stage string) ResourceScope {
+	return ResourceScope{Project: project, Stage: stage}
+}
+
+// NewServiceScope returns the scope of the service level resources
+func NewServiceScope(project string, stage string, service string) ResourceScope {
+	return ResourceScope{Project: project, Stage: stage, Service: service}
+}
+
+// path returns the path of the resource collection of the scope
+func (s ResourceScope) path() string {
+	path := "/v1/project/" + s.Project
+	if s.Stage != "" {
+		path += "/stage/" + s.Stage
+		if s.Service != "" {
+			path += "/service/" + url.QueryEscape(s.Service)
+		}
+	}
+	return path + "/resource"
+}
+
+// String returns a human readable representation of the scope
+func (s ResourceScope) String() string {
+	str := "project " + s.Project
+	if s.Stage != "" {
+		str += ", stage " + s.Stage
+		if s.Service != "" {
+			str += ", service " + s.Service
+		}
+	}
+	return str
+}
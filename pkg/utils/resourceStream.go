@@ -0,0 +1,369 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+const resourceContentKey = "resourceContent"
+
+// GetResourceContent retrieves the decoded content of a resource of the scope. If version is empty,
+// the latest version is returned.
+func (r *ResourceHandler) GetResourceContent(ctx context.Context, scope ResourceScope, resourceURI string, version string) ([]byte, error) {
+	ctx = withOperation(ctx, "GetResourceContent")
+	var buf bytes.Buffer
+	if _, err := r.ReadResourceContent(ctx, scope, resourceURI, version, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadResourceContent retrieves a resource of the scope and streams its decoded content to w without
+// keeping the encoded content in memory. The returned resource contains the URI and version, but no content.
+// If version is empty, the latest version is returned.
+func (r *ResourceHandler) ReadResourceContent(ctx context.Context, scope ResourceScope, resourceURI string, version string, w io.Writer) (*models.VersionedResource, error) {
+	ctx = withOperation(ctx, "ReadResourceContent")
+	uri := r.resourceURI(scope, resourceURI)
+	if version != "" {
+		uri += "?" + versionParam + "=" + url.QueryEscape(version)
+	}
+	resp, err := doStreamingRequest(ctx, "GET", uri, nil, true, nil, r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return decodeResourceStream(resp.Body, w)
+}
+
+// WriteResourceBytes creates or updates a resource of the scope with the provided content
+func (r *ResourceHandler) WriteResourceBytes(ctx context.Context, scope ResourceScope, resourceURI string, content []byte) (string, error) {
+	ctx = withOperation(ctx, "WriteResourceBytes")
+	return r.WriteResourceContent(ctx, scope, resourceURI, bytes.NewReader(content))
+}
+
+// WriteResourceContent creates or updates a resource of the scope with the content read from content.
+// The content is encoded while it is uploaded. Failed uploads are only retried if content implements io.Seeker.
+func (r *ResourceHandler) WriteResourceContent(ctx context.Context, scope ResourceScope, resourceURI string, content io.Reader) (string, error) {
+	ctx = withOperation(ctx, "WriteResourceContent")
+	body, replayable, err := newResourceBody(resourceURI, content)
+	if err != nil {
+		return "", err
+	}
+	resp, err := doStreamingRequest(ctx, "PUT", r.resourceURI(scope, resourceURI), body, replayable, nil, r)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var version models.Version
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", err
+	}
+	return version.Version, nil
+}
+
+// IsBinaryContent reports whether the content of a resource is binary, e.g. a packaged Helm chart,
+// rather than text
+func IsBinaryContent(content []byte) bool {
+	return bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content)
+}
+
+// newResourceBody returns a bodyFunc producing the JSON representation of a resource whose content is
+// base64 encoded on the fly. The body is replayable if content is an io.Seeker. Before content is rewound
+// for another attempt, the body of the previous attempt is closed and its encoding goroutine has finished.
+func newResourceBody(resourceURI string, content io.Reader) (bodyFunc, bool, error) {
+	uri, err := json.Marshal(resourceURI)
+	if err != nil {
+		return nil, false, err
+	}
+
+	seeker, replayable := content.(io.Seeker)
+	var start int64
+	if replayable {
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			return nil, false, err
+		}
+	}
+
+	var previous *io.PipeReader
+	var previousDone chan struct{}
+	return func() (io.Reader, error) {
+		if previous != nil {
+			if !replayable {
+				return nil, errors.New("resource content cannot be read twice")
+			}
+			previous.CloseWithError(errors.New("resource content is sent again"))
+			<-previousDone
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+
+		pr, pw := io.Pipe()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			pw.CloseWithError(writeResourceJSON(pw, uri, content))
+		}()
+		previous, previousDone = pr, done
+		return pr, nil
+	}, replayable, nil
+}
+
+func writeResourceJSON(w io.Writer, uri []byte, content io.Reader) error {
+	if _, err := fmt.Fprintf(w, `{"resourceURI":%s,"%s":"`, uri, resourceContentKey); err != nil {
+		return err
+	}
+	encoder := b64.NewEncoder(b64.StdEncoding, w)
+	if _, err := io.Copy(encoder, content); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, `"}`)
+	return err
+}
+
+// decodeResourceStream decodes the JSON representation of a resource. The base64 encoded content is
+// decoded while it is read and written to w, all other fields are returned as models.VersionedResource.
+func decodeResourceStream(body io.Reader, w io.Writer) (*models.VersionedResource, error) {
+	br := bufio.NewReader(body)
+	var meta bytes.Buffer
+
+	if err := expectByte(br, '{', &meta); err != nil {
+		return nil, err
+	}
+	first := true
+	for {
+		c, err := peekNonSpace(br)
+		if err != nil {
+			return nil, err
+		}
+		if c == '}' {
+			br.ReadByte()
+			meta.WriteByte('}')
+			break
+		}
+		if !first {
+			if err := expectByte(br, ',', &meta); err != nil {
+				return nil, err
+			}
+		}
+		first = false
+
+		var key bytes.Buffer
+		if err := copyJSONString(br, &key); err != nil {
+			return nil, err
+		}
+		meta.Write(key.Bytes())
+		if err := expectByte(br, ':', &meta); err != nil {
+			return nil, err
+		}
+
+		if c, err = peekNonSpace(br); err != nil {
+			return nil, err
+		}
+		if key.String() == `"`+resourceContentKey+`"` && c == '"' {
+			br.ReadByte()
+			content := &jsonStringReader{br: br}
+			if _, err := io.Copy(w, b64.NewDecoder(b64.StdEncoding, content)); err != nil {
+				return nil, err
+			}
+			if !content.done {
+				return nil, errors.New("resource content is not valid base64")
+			}
+			meta.WriteString(`""`)
+			continue
+		}
+		if err := copyJSONValue(br, &meta); err != nil {
+			return nil, err
+		}
+	}
+
+	var resource models.VersionedResource
+	if err := json.Unmarshal(meta.Bytes(), &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return c, br.UnreadByte()
+	}
+}
+
+func expectByte(br *bufio.Reader, expected byte, out *bytes.Buffer) error {
+	c, err := peekNonSpace(br)
+	if err != nil {
+		return err
+	}
+	if c != expected {
+		return fmt.Errorf("invalid resource: expected '%c' but found '%c'", expected, c)
+	}
+	br.ReadByte()
+	out.WriteByte(c)
+	return nil
+}
+
+// copyJSONString copies a JSON string including its quotes
+func copyJSONString(br *bufio.Reader, out *bytes.Buffer) error {
+	if err := expectByte(br, '"', out); err != nil {
+		return err
+	}
+	escaped := false
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		out.WriteByte(c)
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			return nil
+		}
+	}
+}
+
+// copyJSONValue copies a JSON value of any type
+func copyJSONValue(br *bufio.Reader, out *bytes.Buffer) error {
+	c, err := peekNonSpace(br)
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '"':
+		return copyJSONString(br, out)
+	case '{', '[':
+		depth := 0
+		for {
+			c, err := peekNonSpace(br)
+			if err != nil {
+				return err
+			}
+			if c == '"' {
+				if err := copyJSONString(br, out); err != nil {
+					return err
+				}
+				continue
+			}
+			br.ReadByte()
+			out.WriteByte(c)
+			switch c {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return nil
+				}
+			}
+		}
+	default:
+		for {
+			c, err := br.ReadByte()
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			switch c {
+			case ',', '}', ']', ' ', '\t', '\r', '\n':
+				return br.UnreadByte()
+			}
+			out.WriteByte(c)
+		}
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// jsonStringReader reads the unescaped characters of a JSON string up to its closing quote
+type jsonStringReader struct {
+	br   *bufio.Reader
+	done bool
+}
+
+func (s *jsonStringReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if s.done {
+			break
+		}
+		c, err := s.br.ReadByte()
+		if err != nil {
+			return n, unexpectedEOF(err)
+		}
+		switch c {
+		case '"':
+			s.done = true
+			continue
+		case '\\':
+			if c, err = s.unescape(); err != nil {
+				return n, err
+			}
+		}
+		p[n] = c
+		n++
+	}
+	if n == 0 && s.done {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// unescape reads an escape sequence. Base64 only consists of ASCII characters, so
+// escape sequences producing other characters are rejected.
+func (s *jsonStringReader) unescape() (byte, error) {
+	c, err := s.br.ReadByte()
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	switch c {
+	case '"', '\\', '/':
+		return c, nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 'u':
+		hex := make([]byte, 4)
+		if _, err := io.ReadFull(s.br, hex); err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		code, err := strconv.ParseUint(string(hex), 16, 7)
+		if err != nil {
+			return 0, fmt.Errorf("invalid escape sequence in resource content: \\u%s", hex)
+		}
+		return byte(code), nil
+	}
+	return 0, fmt.Errorf("invalid escape sequence in resource content: \\%c", c)
+}
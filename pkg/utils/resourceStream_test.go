@@ -0,0 +1,336 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	b64 "encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/models"
+)
+
+func TestDecodeResourceStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantURI     string
+		wantContent string
+		wantVersion string
+		wantErr     bool
+	}{
+		{
+			name:        "content after resourceURI",
+			body:        `{"resourceURI":"values.yaml","resourceContent":"cmVwbGljYXM6IDE="}`,
+			wantURI:     "values.yaml",
+			wantContent: "replicas: 1",
+		},
+		{
+			name:        "content before resourceURI",
+			body:        `{"resourceContent":"cmVwbGljYXM6IDE=","resourceURI":"values.yaml"}`,
+			wantURI:     "values.yaml",
+			wantContent: "replicas: 1",
+		},
+		{
+			name:        "whitespace between tokens",
+			body:        " {\n\t\"resourceURI\" : \"values.yaml\" ,\r\n \"resourceContent\" : \"YQ==\" \n} ",
+			wantURI:     "values.yaml",
+			wantContent: "a",
+		},
+		{
+			name:        "escaped resourceURI",
+			body:        `{"resourceURI":"charts\/carts \"v1\"\u00e9.tgz","resourceContent":"YQ=="}`,
+			wantURI:     `charts/carts "v1"é.tgz`,
+			wantContent: "a",
+		},
+		{
+			name:        "escaped content",
+			body:        `{"resourceURI":"a","resourceContent":"\u0059\u0051=="}`,
+			wantURI:     "a",
+			wantContent: "a",
+		},
+		{
+			name:        "escaped slash in content",
+			body:        `{"resourceURI":"a","resourceContent":"\/w=="}`,
+			wantURI:     "a",
+			wantContent: "\xff",
+		},
+		{
+			name:        "nested metadata",
+			body:        `{"metadata":{"version":"abc","upstream":{"refs":["}",{"x":"]"}],"n":1.5e3,"ok":true}},"resourceURI":"a","resourceContent":"YQ=="}`,
+			wantURI:     "a",
+			wantContent: "a",
+			wantVersion: "abc",
+		},
+		{
+			name:        "empty content",
+			body:        `{"resourceURI":"a","resourceContent":""}`,
+			wantURI:     "a",
+			wantContent: "",
+		},
+		{
+			name:        "no content",
+			body:        `{"resourceURI":"a"}`,
+			wantURI:     "a",
+			wantContent: "",
+		},
+		{
+			name:    "truncated content",
+			body:    `{"resourceURI":"a","resourceContent":"cmVwbGlj`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated object",
+			body:    `{"resourceURI":"a","resourceContent":"YQ=="`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated metadata",
+			body:    `{"metadata":{"version":"abc"`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid base64",
+			body:    `{"resourceURI":"a","resourceContent":"!!!!"}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing padding",
+			body:    `{"resourceURI":"a","resourceContent":"YQ"}`,
+			wantErr: true,
+		},
+		{
+			name:    "non-ASCII escape in content",
+			body:    `{"resourceURI":"a","resourceContent":"YQ\u00e9="}`,
+			wantErr: true,
+		},
+		{
+			name:    "no object",
+			body:    `["a"]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var content bytes.Buffer
+			resource, err := decodeResourceStream(strings.NewReader(tt.body), &content)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resource.ResourceURI == nil || *resource.ResourceURI != tt.wantURI {
+				t.Errorf("got resourceURI %v, want %q", resource.ResourceURI, tt.wantURI)
+			}
+			if content.String() != tt.wantContent {
+				t.Errorf("got content %q, want %q", content.String(), tt.wantContent)
+			}
+			if resource.ResourceContent != "" {
+				t.Errorf("got encoded content %q in the resource, want none", resource.ResourceContent)
+			}
+			if tt.wantVersion != "" && (resource.Metadata == nil || resource.Metadata.Version != tt.wantVersion) {
+				t.Errorf("got metadata %v, want version %s", resource.Metadata, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestJSONStringReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		wantRest string
+		wantErr  bool
+	}{
+		{name: "plain", input: `abc"rest`, want: "abc", wantRest: "rest"},
+		{name: "empty", input: `"rest`, want: "", wantRest: "rest"},
+		{name: "escaped quote and backslash", input: `a\"b\\c"`, want: `a"b\c`},
+		{name: "escaped slash", input: `a\/b"`, want: "a/b"},
+		{name: "newlines", input: `a\nb\r"`, want: "a\nb\r"},
+		{name: "unicode escapes", input: `\u0041\u007a"`, want: "Az"},
+		{name: "non-ASCII unicode escape", input: `\u00e9"`, wantErr: true},
+		{name: "invalid unicode escape", input: `\u00zz"`, wantErr: true},
+		{name: "unknown escape", input: `\x"`, wantErr: true},
+		{name: "truncated", input: `abc`, wantErr: true},
+		{name: "truncated escape", input: `ab\u00`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			br := bufio.NewReader(strings.NewReader(tt.input))
+			// a small buffer makes Read return in the middle of the string
+			got, err := ioutil.ReadAll(&oneByteReader{&jsonStringReader{br: br}})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if rest, _ := ioutil.ReadAll(br); string(rest) != tt.wantRest {
+				t.Errorf("got rest %q, want %q", rest, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestCopyJSONValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "string", input: `"a,b"}`, want: `"a,b"`},
+		{name: "string with escapes", input: `"a\"}\\"}`, want: `"a\"}\\"`},
+		{name: "number", input: `-1.5e3,`, want: `-1.5e3`},
+		{name: "literal", input: `true}`, want: `true`},
+		{name: "null", input: `null ,`, want: `null`},
+		{name: "object", input: `{"a":1,"b":"}"},`, want: `{"a":1,"b":"}"}`},
+		{name: "nested", input: `{"a":[{"b":["]",{}]}],"c":{"d":{}}}}`, want: `{"a":[{"b":["]",{}]}],"c":{"d":{}}}`},
+		{name: "array", input: `[1, "]", [2]]}`, want: `[1,"]",[2]]`},
+		{name: "truncated string", input: `"abc`, wantErr: true},
+		{name: "truncated object", input: `{"a":{"b":1}`, wantErr: true},
+		{name: "truncated literal", input: `tru`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := copyJSONValue(bufio.NewReader(strings.NewReader(tt.input)), &out)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("got %s, want %s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceStreamRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "empty", size: 0},
+		{name: "one byte", size: 1},
+		{name: "not a multiple of three bytes", size: 4097},
+		{name: "more than 1 MB", size: 3<<20 + 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := make([]byte, tt.size)
+			rand.New(rand.NewSource(int64(tt.size))).Read(content)
+
+			body, _, err := newResourceBody("helm/carts.tgz", bytes.NewReader(content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			reader, err := body()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			encoded, err := ioutil.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var resource models.Resource
+			if err := json.Unmarshal(encoded, &resource); err != nil {
+				t.Fatalf("body is not a valid resource: %v", err)
+			}
+			if resource.ResourceContent != b64.StdEncoding.EncodeToString(content) {
+				t.Error("body does not contain the base64 encoded content")
+			}
+
+			var decoded bytes.Buffer
+			if _, err := decodeResourceStream(bytes.NewReader(encoded), &decoded); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(decoded.Bytes(), content) {
+				t.Errorf("got %d decoded bytes which differ from the %d original bytes", decoded.Len(), len(content))
+			}
+		})
+	}
+}
+
+func TestResourceBodyReplay(t *testing.T) {
+	content := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(content)
+	body, replayable, err := newResourceBody("helm/carts.tgz", bytes.NewReader(content))
+	if err != nil || !replayable {
+		t.Fatalf("got replayable %t and error %v, want a replayable body", replayable, err)
+	}
+
+	// the first attempt is abandoned after reading a part of the body
+	first, err := body()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := io.ReadFull(first, make([]byte, 1000)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, err := body()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	encoded, err := ioutil.ReadAll(second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded bytes.Buffer
+	if _, err := decodeResourceStream(bytes.NewReader(encoded), &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(decoded.Bytes(), content) {
+		t.Error("replayed body does not contain the original content")
+	}
+	if _, err := first.Read(make([]byte, 1)); err == nil {
+		t.Error("got no error when reading the abandoned body, want an error")
+	}
+}
+
+func TestResourceBodyNotReplayable(t *testing.T) {
+	body, replayable, err := newResourceBody("a", ioutil.NopCloser(strings.NewReader("content")))
+	if err != nil || replayable {
+		t.Fatalf("got replayable %t and error %v, want a body which is not replayable", replayable, err)
+	}
+	if _, err := body(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := body(); err == nil {
+		t.Error("got no error for the second attempt, want an error")
+	}
+}
+
+// oneByteReader reads at most one byte per call
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (r *oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return r.r.Read(p[:1])
+}
@@ -0,0 +1,232 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	b64 "encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SyncAction describes what a sync does with a single resource
+type SyncAction int
+
+const (
+	// SyncUnchanged is used for resources whose local and remote content are equal
+	SyncUnchanged SyncAction = iota
+	// SyncCreate is used for local files which do not exist as resource yet
+	SyncCreate
+	// SyncUpdate is used for local files whose content differs from the resource
+	SyncUpdate
+	// SyncDelete is used for resources which do not exist as local file
+	SyncDelete
+)
+
+func (a SyncAction) String() string {
+	return syncActionToString[a]
+}
+
+var syncActionToString = map[SyncAction]string{
+	SyncUnchanged: "unchanged",
+	SyncCreate:    "create",
+	SyncUpdate:    "update",
+	SyncDelete:    "delete",
+}
+
+// SyncOptions configures the sync of a local directory to resources
+type SyncOptions struct {
+	// Suffixes restricts the sync to files and resources ending with one of the suffixes.
+	// If empty, all files are synced.
+	Suffixes []string
+	// IncludeHidden also syncs files and resources whose name or directory starts with a dot, e.g. .git/config.
+	// They are skipped by default.
+	IncludeHidden bool
+	// DeleteRemoteOnly deletes resources which do not exist as local file
+	DeleteRemoteOnly bool
+	// DryRun only prints the plan without changing any resource
+	DryRun bool
+	// Out receives the plan in a dry run. If nil, os.Stdout is used.
+	Out io.Writer
+}
+
+// SyncPlanEntry describes the action taken for a single resource
+type SyncPlanEntry struct {
+	ResourceURI string
+	Action      SyncAction
+}
+
+// SyncDirectory syncs the files of a local directory to the resources of the scope. The path of a file relative
+// to dir is used as resource URI. New and changed files are uploaded, resources without local file are deleted
+// if requested. It returns the plan, which has been executed unless opts.DryRun is set.
+func (r *ResourceHandler) SyncDirectory(ctx context.Context, dir string, scope ResourceScope, opts SyncOptions) ([]SyncPlanEntry, error) {
+	ctx = withOperation(ctx, "SyncDirectory")
+	localFiles, err := getSyncFiles(dir, opts.Suffixes, opts.IncludeHidden)
+	if err != nil {
+		return nil, err
+	}
+
+	// the listing contains the content of the resources, so they are compared by hashing it while listing
+	remoteURIs := map[string]string{}
+	remoteHashes := map[string][]byte{}
+	it := r.IterateResources(ctx, scope, 0)
+	for it.Next() {
+		resource := it.Resource()
+		if resource.ResourceURI == nil {
+			continue
+		}
+		uri := strings.TrimPrefix(*resource.ResourceURI, "/")
+		if !hasAnySuffix(uri, opts.Suffixes) || !opts.IncludeHidden && isHiddenPath(uri) {
+			continue
+		}
+		hash, err := hashResourceContent(resource.ResourceContent)
+		if err != nil {
+			return nil, fmt.Errorf("Error when reading resource %s of %s: %s", uri, scope, err.Error())
+		}
+		remoteURIs[uri] = *resource.ResourceURI
+		remoteHashes[uri] = hash
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	plan := []SyncPlanEntry{}
+	for uri, path := range localFiles {
+		entry := SyncPlanEntry{ResourceURI: uri, Action: SyncCreate}
+		if remoteHash, ok := remoteHashes[uri]; ok {
+			localHash, err := hashFile(path)
+			if err != nil {
+				return nil, err
+			}
+			entry.Action = SyncUnchanged
+			if !bytes.Equal(remoteHash, localHash) {
+				entry.Action = SyncUpdate
+			}
+		}
+		plan = append(plan, entry)
+	}
+	if opts.DeleteRemoteOnly {
+		for uri := range remoteURIs {
+			if _, ok := localFiles[uri]; !ok {
+				plan = append(plan, SyncPlanEntry{ResourceURI: uri, Action: SyncDelete})
+			}
+		}
+	}
+	sort.Slice(plan, func(i, j int) bool {
+		return plan[i].ResourceURI < plan[j].ResourceURI
+	})
+
+	if opts.DryRun {
+		out := opts.Out
+		if out == nil {
+			out = os.Stdout
+		}
+		for _, entry := range plan {
+			fmt.Fprintf(out, "%-9s %s (%s)\n", entry.Action, entry.ResourceURI, scope)
+		}
+		return plan, nil
+	}
+
+	for _, entry := range plan {
+		switch entry.Action {
+		case SyncCreate, SyncUpdate:
+			err = r.uploadFile(ctx, scope, entry.ResourceURI, localFiles[entry.ResourceURI])
+		case SyncDelete:
+			err = r.deleteResource(ctx, r.resourceURI(scope, remoteURIs[entry.ResourceURI]))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error when syncing resource %s of %s: %s", entry.ResourceURI, scope, err.Error())
+		}
+	}
+	return plan, nil
+}
+
+// getSyncFiles returns the regular files of dir matching the suffixes, keyed by their resource URI.
+// Hidden files and directories are skipped unless includeHidden is set.
+func getSyncFiles(dir string, suffixes []string, includeHidden bool) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if !includeHidden && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() && hasAnySuffix(path, suffixes) {
+			files[filepath.ToSlash(rel)] = path
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// isHiddenPath reports whether a segment of the resource URI starts with a dot
+func isHiddenPath(uri string) bool {
+	for _, segment := range strings.Split(uri, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnySuffix(s string, suffixes []string) bool {
+	if len(suffixes) == 0 {
+		return true
+	}
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashResourceContent returns the hash of the base64 encoded content of a resource
+func hashResourceContent(content string) ([]byte, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, b64.NewDecoder(b64.StdEncoding, strings.NewReader(content))); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+func hashFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+func (r *ResourceHandler) uploadFile(ctx context.Context, scope ResourceScope, resourceURI string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = r.WriteResourceContent(ctx, scope, resourceURI, file)
+	return err
+}
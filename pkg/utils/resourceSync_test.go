@@ -0,0 +1,155 @@
+package utils_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+)
+
+func TestSyncDirectory(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            utils.SyncOptions
+		wantPlan        string
+		wantResources   map[string]string
+		wantNoResources []string
+		// wantRequests is the number of requests sent for the sync, if it is checked
+		wantRequests int
+	}{
+		{
+			name:     "dry run",
+			opts:     utils.SyncOptions{Suffixes: []string{".yaml"}, DeleteRemoteOnly: true, DryRun: true},
+			wantPlan: "changed.yaml:update,new.yaml:create,remote-only.yaml:delete,sub/nested.yaml:create,unchanged.yaml:unchanged",
+			wantResources: map[string]string{
+				"changed.yaml":     "remote",
+				"remote-only.yaml": "remote",
+			},
+			wantNoResources: []string{"new.yaml", "sub/nested.yaml"},
+			// the resources are compared while listing them, without downloading them again
+			wantRequests: 1,
+		},
+		{
+			name:     "sync without deleting",
+			opts:     utils.SyncOptions{Suffixes: []string{".yaml"}},
+			wantPlan: "changed.yaml:update,new.yaml:create,sub/nested.yaml:create,unchanged.yaml:unchanged",
+			wantResources: map[string]string{
+				"changed.yaml":     "local",
+				"new.yaml":         "new",
+				"sub/nested.yaml":  "nested",
+				"unchanged.yaml":   "same",
+				"remote-only.yaml": "remote",
+			},
+			wantNoResources: []string{"notes.txt"},
+		},
+		{
+			name:     "sync with deleting",
+			opts:     utils.SyncOptions{Suffixes: []string{".yaml"}, DeleteRemoteOnly: true},
+			wantPlan: "changed.yaml:update,new.yaml:create,remote-only.yaml:delete,sub/nested.yaml:create,unchanged.yaml:unchanged",
+			wantResources: map[string]string{
+				"changed.yaml":    "local",
+				"remote-only.txt": "remote",
+			},
+			wantNoResources: []string{"remote-only.yaml"},
+		},
+		{
+			name:     "sync all files",
+			opts:     utils.SyncOptions{DeleteRemoteOnly: true},
+			wantPlan: "changed.yaml:update,new.yaml:create,notes.txt:create,remote-only.txt:delete,remote-only.yaml:delete,sub/nested.yaml:create,unchanged.yaml:unchanged",
+			wantResources: map[string]string{
+				"notes.txt":  "notes",
+				".gitignore": "remote",
+			},
+			wantNoResources: []string{".git/config", ".env"},
+		},
+		{
+			name:     "sync hidden files",
+			opts:     utils.SyncOptions{Suffixes: []string{"config", ".env", ".gitignore"}, IncludeHidden: true, DeleteRemoteOnly: true},
+			wantPlan: ".env:create,.git/config:create,.gitignore:delete",
+			wantResources: map[string]string{
+				".git/config": "[core]",
+				".env":        "secret",
+			},
+			wantNoResources: []string{".gitignore"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "keptn-sync")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			writeTestFiles(t, dir, map[string]string{
+				"unchanged.yaml":  "same",
+				"changed.yaml":    "local",
+				"new.yaml":        "new",
+				"sub/nested.yaml": "nested",
+				"notes.txt":       "notes",
+				".git/config":     "[core]",
+				".env":            "secret",
+			})
+
+			srv := configservicetest.NewServer()
+			defer srv.Close()
+			srv.SetResource("sockshop", "dev", "carts", "unchanged.yaml", []byte("same"))
+			srv.SetResource("sockshop", "dev", "carts", "changed.yaml", []byte("remote"))
+			srv.SetResource("sockshop", "dev", "carts", "remote-only.yaml", []byte("remote"))
+			srv.SetResource("sockshop", "dev", "carts", "remote-only.txt", []byte("remote"))
+			srv.SetResource("sockshop", "dev", "carts", ".gitignore", []byte("remote"))
+			requests := srv.RequestCount()
+
+			var out bytes.Buffer
+			tt.opts.Out = &out
+			plan, err := utils.NewResourceHandler(srv.URL).SyncDirectory(context.Background(), dir, utils.NewServiceScope("sockshop", "dev", "carts"), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			entries := []string{}
+			for _, entry := range plan {
+				entries = append(entries, entry.ResourceURI+":"+entry.Action.String())
+			}
+			if strings.Join(entries, ",") != tt.wantPlan {
+				t.Errorf("got plan %s, want %s", strings.Join(entries, ","), tt.wantPlan)
+			}
+			if tt.wantRequests > 0 && srv.RequestCount()-requests != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", srv.RequestCount()-requests, tt.wantRequests)
+			}
+			if tt.opts.DryRun && strings.Count(out.String(), "\n") != len(plan) {
+				t.Errorf("got output %q, want one line per plan entry", out.String())
+			}
+			if !tt.opts.DryRun && out.Len() > 0 {
+				t.Errorf("got output %q, want none", out.String())
+			}
+			for uri, want := range tt.wantResources {
+				if content, ok := srv.GetResource("sockshop", "dev", "carts", uri); !ok || string(content) != want {
+					t.Errorf("got content %q of resource %s, want %q", content, uri, want)
+				}
+			}
+			for _, uri := range tt.wantNoResources {
+				if _, ok := srv.GetResource("sockshop", "dev", "carts", uri); ok {
+					t.Errorf("got resource %s, want none", uri)
+				}
+			}
+		})
+	}
+}
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -2,14 +2,13 @@ package utils
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	b64 "encoding/base64"
 	"encoding/json"
 	"errors"
-	"io/ioutil"
+	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 
 	"github.com/keptn/go-utils/pkg/models"
 )
@@ -21,39 +20,40 @@ type ResourceHandler struct {
 	AuthHeader string
 	HTTPClient *http.Client
 	Scheme     string
+	// RetryPolicy configures the retries of failed requests. Requests are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+	// Instrumentation observes all requests, e.g. for recording metrics. It is optional.
+	Instrumentation Instrumentation
+	// Authenticator adds the credentials to all requests. If it is nil, AuthToken is set in AuthHeader.
+	Authenticator Authenticator
 }
 
+// versionParam is the query parameter selecting the version (commit ID) of a resource
+const versionParam = "version"
+
+// ErrVersionsNotSupported is returned if the configuration service ignores the version of a resource request.
+// Reading resources at a version and listing their version history require a configuration service which
+// serves GET /resource/{resourceURI}?version={commitID} and GET /resource/{resourceURI}/version in each scope,
+// as configservicetest.Server does. Conditional writes additionally require that PUT /resource/{resourceURI}
+// honors If-Match. Configuration services without resource versioning return the latest content or not found
+// instead.
+var ErrVersionsNotSupported = errors.New("The configuration service does not support resource versions")
+
+// maxModifyAttempts limits how often ModifyResource re-reads a resource after a conflict
+const maxModifyAttempts = 5
+
 type resourceRequest struct {
 	Resources []*models.Resource `json:"resources"`
 }
 
 // NewResourceHandler returns a new ResourceHandler
 func NewResourceHandler(baseURL string) *ResourceHandler {
-	baseURL = strings.TrimPrefix(baseURL, "http://")
-	baseURL = strings.TrimPrefix(baseURL, "https://")
-	return &ResourceHandler{
-		BaseURL:    baseURL,
-		AuthHeader: "",
-		AuthToken:  "",
-		HTTPClient: &http.Client{},
-		Scheme:     "http",
-	}
+	return NewAuthenticatedResourceHandler(baseURL, "", "", nil, "http")
 }
 
 // NewAuthenticatedResourceHandler returns a new ResourceHandler that authenticates at the endpoint via the provided token
 func NewAuthenticatedResourceHandler(baseURL string, authToken string, authHeader string, httpClient *http.Client, scheme string) *ResourceHandler {
-	if httpClient == nil {
-		httpClient = &http.Client{}
-	}
-	baseURL = strings.TrimPrefix(baseURL, "http://")
-	baseURL = strings.TrimPrefix(baseURL, "https://")
-	return &ResourceHandler{
-		BaseURL:    baseURL,
-		AuthHeader: authHeader,
-		AuthToken:  authToken,
-		HTTPClient: httpClient,
-		Scheme:     scheme,
-	}
+	return newClientFromHandlerArgs(baseURL, authToken, authHeader, httpClient, scheme).Resources()
 }
 
 func (r *ResourceHandler) getBaseURL() string {
@@ -68,94 +68,207 @@ func (r *ResourceHandler) getAuthHeader() string {
 	return r.AuthHeader
 }
 
-func (r *ResourceHandler) getHTTPClient() *http.Client {
-	return r.HTTPClient
+func (r *ResourceHandler) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return r.HTTPClient.Do(req.WithContext(ctx))
+}
+
+func (r *ResourceHandler) getRetryPolicy() *RetryPolicy {
+	return r.RetryPolicy
+}
+
+func (r *ResourceHandler) getInstrumentation() Instrumentation {
+	return r.Instrumentation
+}
+
+func (r *ResourceHandler) getAuthenticator() Authenticator {
+	return r.Authenticator
+}
+
+// ConfigureTLS applies the TLS options to the HTTP client of the ResourceHandler.
+// Other HTTP clients of the process are not affected.
+func (r *ResourceHandler) ConfigureTLS(opts TLSOptions) error {
+	httpClient, err := newTLSHTTPClient(r.HTTPClient, opts)
+	if err != nil {
+		return err
+	}
+	r.HTTPClient = httpClient
+	return nil
 }
 
 // CreateProjectResources creates multiple project resources
 func (r *ResourceHandler) CreateProjectResources(project string, resources []*models.Resource) (string, error) {
-	return r.createResources(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/resource", resources)
+	return r.CreateProjectResourcesWithContext(context.Background(), project, resources)
+}
+
+// CreateProjectResourcesWithContext creates multiple project resources
+func (r *ResourceHandler) CreateProjectResourcesWithContext(ctx context.Context, project string, resources []*models.Resource) (string, error) {
+	ctx = withOperation(ctx, "CreateProjectResources")
+	return r.createResources(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/resource", resources)
 }
 
 // GetProjectResource retrieves a project resource from the configuration service
 func (r *ResourceHandler) GetProjectResource(project string, resourceURI string) (*models.Resource, error) {
-	return r.getResource(r.Scheme + "://" + r.BaseURL + "/v1/project/" + project + "/resource/" + url.QueryEscape(resourceURI))
+	return r.GetProjectResourceWithContext(context.Background(), project, resourceURI)
+}
+
+// GetProjectResourceWithContext retrieves a project resource from the configuration service
+func (r *ResourceHandler) GetProjectResourceWithContext(ctx context.Context, project string, resourceURI string) (*models.Resource, error) {
+	ctx = withOperation(ctx, "GetProjectResource")
+	return r.getResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/resource/"+url.QueryEscape(resourceURI))
 }
 
 // UpdateProjectResource updates a project resource
 func (r *ResourceHandler) UpdateProjectResource(project string, resource *models.Resource) (string, error) {
-	return r.updateResource(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/resource/"+url.QueryEscape(*resource.ResourceURI), resource)
+	return r.UpdateProjectResourceWithContext(context.Background(), project, resource)
+}
+
+// UpdateProjectResourceWithContext updates a project resource
+func (r *ResourceHandler) UpdateProjectResourceWithContext(ctx context.Context, project string, resource *models.Resource) (string, error) {
+	ctx = withOperation(ctx, "UpdateProjectResource")
+	return r.updateResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/resource/"+url.QueryEscape(*resource.ResourceURI), resource)
 }
 
 // DeleteProjectResource deletes a project resource
 func (r *ResourceHandler) DeleteProjectResource(project string, resourceURI string) error {
-	return r.deleteResource(r.Scheme + "://" + r.BaseURL + "/v1/project/" + project + "/resource/" + url.QueryEscape(resourceURI))
+	return r.DeleteProjectResourceWithContext(context.Background(), project, resourceURI)
+}
+
+// DeleteProjectResourceWithContext deletes a project resource
+func (r *ResourceHandler) DeleteProjectResourceWithContext(ctx context.Context, project string, resourceURI string) error {
+	ctx = withOperation(ctx, "DeleteProjectResource")
+	return r.deleteResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/resource/"+url.QueryEscape(resourceURI))
 }
 
 // UpdateProjectResources updates multiple project resources
 func (r *ResourceHandler) UpdateProjectResources(project string, resources []*models.Resource) (string, error) {
-	return r.updateResources(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/resource", resources)
+	return r.UpdateProjectResourcesWithContext(context.Background(), project, resources)
+}
+
+// UpdateProjectResourcesWithContext updates multiple project resources
+func (r *ResourceHandler) UpdateProjectResourcesWithContext(ctx context.Context, project string, resources []*models.Resource) (string, error) {
+	ctx = withOperation(ctx, "UpdateProjectResources")
+	return r.updateResources(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/resource", resources)
 }
 
 // CreateStageResources creates a stage resource
 func (r *ResourceHandler) CreateStageResources(project string, stage string, resources []*models.Resource) (string, error) {
-	return r.createResources(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/resource", resources)
+	return r.CreateStageResourcesWithContext(context.Background(), project, stage, resources)
+}
+
+// CreateStageResourcesWithContext creates a stage resource
+func (r *ResourceHandler) CreateStageResourcesWithContext(ctx context.Context, project string, stage string, resources []*models.Resource) (string, error) {
+	ctx = withOperation(ctx, "CreateStageResources")
+	return r.createResources(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/resource", resources)
 }
 
 // GetStageResource retrieves a stage resource from the configuration service
 func (r *ResourceHandler) GetStageResource(project string, stage string, resourceURI string) (*models.Resource, error) {
-	return r.getResource(r.Scheme + "://" + r.BaseURL + "/v1/project/" + project + "/stage/" + stage + "/resource/" + url.QueryEscape(resourceURI))
+	return r.GetStageResourceWithContext(context.Background(), project, stage, resourceURI)
+}
+
+// GetStageResourceWithContext retrieves a stage resource from the configuration service
+func (r *ResourceHandler) GetStageResourceWithContext(ctx context.Context, project string, stage string, resourceURI string) (*models.Resource, error) {
+	ctx = withOperation(ctx, "GetStageResource")
+	return r.getResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/resource/"+url.QueryEscape(resourceURI))
 }
 
 // UpdateStageResource updates a stage resource
 func (r *ResourceHandler) UpdateStageResource(project string, stage string, resource *models.Resource) (string, error) {
-	return r.updateResource(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/resource/"+url.QueryEscape(*resource.ResourceURI), resource)
+	return r.UpdateStageResourceWithContext(context.Background(), project, stage, resource)
+}
+
+// UpdateStageResourceWithContext updates a stage resource
+func (r *ResourceHandler) UpdateStageResourceWithContext(ctx context.Context, project string, stage string, resource *models.Resource) (string, error) {
+	ctx = withOperation(ctx, "UpdateStageResource")
+	return r.updateResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/resource/"+url.QueryEscape(*resource.ResourceURI), resource)
 }
 
 // UpdateStageResources updates multiple stage resources
 func (r *ResourceHandler) UpdateStageResources(project string, stage string, resources []*models.Resource) (string, error) {
-	return r.updateResources(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/resource", resources)
+	return r.UpdateStageResourcesWithContext(context.Background(), project, stage, resources)
+}
+
+// UpdateStageResourcesWithContext updates multiple stage resources
+func (r *ResourceHandler) UpdateStageResourcesWithContext(ctx context.Context, project string, stage string, resources []*models.Resource) (string, error) {
+	ctx = withOperation(ctx, "UpdateStageResources")
+	return r.updateResources(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/resource", resources)
 }
 
 // DeleteStageResource deletes a stage resource
 func (r *ResourceHandler) DeleteStageResource(project string, stage string, resourceURI string) error {
-	return r.deleteResource(r.Scheme + "://" + r.BaseURL + "/v1/project/" + project + "/stage/" + stage + "/resource/" + url.QueryEscape(resourceURI))
+	return r.DeleteStageResourceWithContext(context.Background(), project, stage, resourceURI)
+}
+
+// DeleteStageResourceWithContext deletes a stage resource
+func (r *ResourceHandler) DeleteStageResourceWithContext(ctx context.Context, project string, stage string, resourceURI string) error {
+	ctx = withOperation(ctx, "DeleteStageResource")
+	return r.deleteResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/resource/"+url.QueryEscape(resourceURI))
 }
 
 // CreateServiceResources creates a service resource
 func (r *ResourceHandler) CreateServiceResources(project string, stage string, service string, resources []*models.Resource) (string, error) {
-	return r.createResources(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+service+"/resource", resources)
+	return r.CreateServiceResourcesWithContext(context.Background(), project, stage, service, resources)
+}
+
+// CreateServiceResourcesWithContext creates a service resource
+func (r *ResourceHandler) CreateServiceResourcesWithContext(ctx context.Context, project string, stage string, service string, resources []*models.Resource) (string, error) {
+	ctx = withOperation(ctx, "CreateServiceResources")
+	return r.createResources(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+service+"/resource", resources)
 }
 
 // GetServiceResource retrieves a service resource from the configuration service
 func (r *ResourceHandler) GetServiceResource(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
-	return r.getResource(r.Scheme + "://" + r.BaseURL + "/v1/project/" + project + "/stage/" + stage + "/service/" + url.QueryEscape(service) + "/resource/" + url.QueryEscape(resourceURI))
+	return r.GetServiceResourceWithContext(context.Background(), project, stage, service, resourceURI)
+}
+
+// GetServiceResourceWithContext retrieves a service resource from the configuration service
+func (r *ResourceHandler) GetServiceResourceWithContext(ctx context.Context, project string, stage string, service string, resourceURI string) (*models.Resource, error) {
+	ctx = withOperation(ctx, "GetServiceResource")
+	return r.getResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(service)+"/resource/"+url.QueryEscape(resourceURI))
 }
 
 // UpdateServiceResource updates a service resource
 func (r *ResourceHandler) UpdateServiceResource(project string, stage string, service string, resource *models.Resource) (string, error) {
-	return r.updateResource(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(service)+"/resource/"+url.QueryEscape(*resource.ResourceURI), resource)
+	return r.UpdateServiceResourceWithContext(context.Background(), project, stage, service, resource)
+}
+
+// UpdateServiceResourceWithContext updates a service resource
+func (r *ResourceHandler) UpdateServiceResourceWithContext(ctx context.Context, project string, stage string, service string, resource *models.Resource) (string, error) {
+	ctx = withOperation(ctx, "UpdateServiceResource")
+	return r.updateResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(service)+"/resource/"+url.QueryEscape(*resource.ResourceURI), resource)
 }
 
 // UpdateServiceResources updates multiple service resources
 func (r *ResourceHandler) UpdateServiceResources(project string, stage string, service string, resources []*models.Resource) (string, error) {
-	return r.updateResources(r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(service)+"/resource", resources)
+	return r.UpdateServiceResourcesWithContext(context.Background(), project, stage, service, resources)
+}
+
+// UpdateServiceResourcesWithContext updates multiple service resources
+func (r *ResourceHandler) UpdateServiceResourcesWithContext(ctx context.Context, project string, stage string, service string, resources []*models.Resource) (string, error) {
+	ctx = withOperation(ctx, "UpdateServiceResources")
+	return r.updateResources(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(service)+"/resource", resources)
 }
 
 // DeleteServiceResource deletes a service resource
 func (r *ResourceHandler) DeleteServiceResource(project string, stage string, service string, resourceURI string) error {
-	return r.deleteResource(r.Scheme + "://" + r.BaseURL + "/v1/project/" + project + "/stage/" + stage + "/service/" + url.QueryEscape(service) + "/resource/" + url.QueryEscape(resourceURI))
+	return r.DeleteServiceResourceWithContext(context.Background(), project, stage, service, resourceURI)
 }
 
-func (r *ResourceHandler) createResources(uri string, resources []*models.Resource) (string, error) {
-	return r.writeResources(uri, "POST", resources)
+// DeleteServiceResourceWithContext deletes a service resource
+func (r *ResourceHandler) DeleteServiceResourceWithContext(ctx context.Context, project string, stage string, service string, resourceURI string) error {
+	ctx = withOperation(ctx, "DeleteServiceResource")
+	return r.deleteResource(ctx, r.Scheme+"://"+r.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(service)+"/resource/"+url.QueryEscape(resourceURI))
 }
 
-func (r *ResourceHandler) updateResources(uri string, resources []*models.Resource) (string, error) {
-	return r.writeResources(uri, "PUT", resources)
+func (r *ResourceHandler) createResources(ctx context.Context, uri string, resources []*models.Resource) (string, error) {
+	return r.writeResources(ctx, uri, "POST", resources)
 }
 
-func (r *ResourceHandler) writeResources(uri string, method string, resources []*models.Resource) (string, error) {
+func (r *ResourceHandler) updateResources(ctx context.Context, uri string, resources []*models.Resource) (string, error) {
+	return r.writeResources(ctx, uri, "PUT", resources)
+}
+
+func (r *ResourceHandler) writeResources(ctx context.Context, uri string, method string, resources []*models.Resource) (string, error) {
 
 	copiedResources := make([]*models.Resource, len(resources), len(resources))
 	for i, val := range resources {
@@ -169,25 +282,38 @@ func (r *ResourceHandler) writeResources(uri string, method string, resources []
 	if err != nil {
 		return "", err
 	}
-	req, err := http.NewRequest(method, uri, bytes.NewBuffer(resourceStr))
-	req.Header.Set("Content-Type", "application/json")
-	addAuthHeader(req, r)
+	return r.writeAndParseVersion(ctx, method, uri, resourceStr, nil)
+}
+
+func (r *ResourceHandler) updateResource(ctx context.Context, uri string, resource *models.Resource) (string, error) {
+	return r.writeResource(ctx, uri, "PUT", resource, "")
+}
 
-	resp, err := r.HTTPClient.Do(req)
+// writeResource writes a single resource. If expectedVersion is not empty, the
+// configuration service rejects the write if the stored version differs.
+func (r *ResourceHandler) writeResource(ctx context.Context, uri string, method string, resource *models.Resource, expectedVersion string) (string, error) {
+
+	copiedResource := &models.Resource{ResourceURI: resource.ResourceURI, ResourceContent: b64.StdEncoding.EncodeToString([]byte(resource.ResourceContent))}
+
+	resourceStr, err := json.Marshal(copiedResource)
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
+	var header http.Header
+	if expectedVersion != "" {
+		header = http.Header{}
+		header.Set("If-Match", expectedVersion)
+	}
+	return r.writeAndParseVersion(ctx, method, uri, resourceStr, header)
+}
 
-	var version models.Version
-	body, err := ioutil.ReadAll(resp.Body)
+func (r *ResourceHandler) writeAndParseVersion(ctx context.Context, method string, uri string, data []byte, header http.Header) (string, error) {
+	body, err := doRequestWithHeader(ctx, method, uri, data, header, r)
 	if err != nil {
 		return "", err
 	}
-	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-		return "", errors.New(string(body))
-	}
 
+	var version models.Version
 	err = json.Unmarshal(body, &version)
 	if err != nil {
 		return "", err
@@ -196,141 +322,311 @@ func (r *ResourceHandler) writeResources(uri string, method string, resources []
 	return version.Version, nil
 }
 
-func (r *ResourceHandler) updateResource(uri string, resource *models.Resource) (string, error) {
-	return r.writeResource(uri, "PUT", resource)
-}
-
-func (r *ResourceHandler) writeResource(uri string, method string, resource *models.Resource) (string, error) {
-
-	copiedResource := &models.Resource{ResourceURI: resource.ResourceURI, ResourceContent: b64.StdEncoding.EncodeToString([]byte(resource.ResourceContent))}
-
-	resourceStr, err := json.Marshal(copiedResource)
+func (r *ResourceHandler) getResource(ctx context.Context, uri string) (*models.Resource, error) {
+	resource, err := r.getVersionedResource(ctx, uri)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	req, err := http.NewRequest(method, uri, bytes.NewBuffer(resourceStr))
-	req.Header.Set("Content-Type", "application/json")
-	addAuthHeader(req, r)
+	return &resource.Resource, nil
+}
 
-	resp, err := r.HTTPClient.Do(req)
+func (r *ResourceHandler) getVersionedResource(ctx context.Context, uri string) (*models.VersionedResource, error) {
+	resp, err := doStreamingRequest(ctx, "GET", uri, nil, true, nil, r)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
-	var version models.Version
-	body, err := ioutil.ReadAll(resp.Body)
+
+	var content bytes.Buffer
+	resource, err := decodeResourceStream(resp.Body, &content)
 	if err != nil {
-		return "", err
-	}
-	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-		return "", errors.New(string(body))
+		return nil, err
 	}
+	resource.ResourceContent = content.String()
+	return resource, nil
+}
 
-	err = json.Unmarshal(body, &version)
-	if err != nil {
-		return "", err
+func (r *ResourceHandler) deleteResource(ctx context.Context, uri string) error {
+	return delete(ctx, uri, r)
+}
+
+// GetAllStageResources returns a list of all resources.
+func (r *ResourceHandler) GetAllStageResources(project string, stage string) ([]*models.Resource, error) {
+	return r.GetAllStageResourcesWithContext(context.Background(), project, stage)
+}
+
+// GetAllStageResourcesWithContext returns a list of all resources.
+func (r *ResourceHandler) GetAllStageResourcesWithContext(ctx context.Context, project string, stage string) ([]*models.Resource, error) {
+	ctx = withOperation(ctx, "GetAllStageResources")
+	return r.GetAllResourcesWithContext(ctx, NewStageScope(project, stage))
+}
+
+// IterateStageResources returns an iterator over all resources of a stage, which fetches pages of the provided size on demand.
+// If pageSize is 0, the page size of the configuration service is used.
+func (r *ResourceHandler) IterateStageResources(ctx context.Context, project string, stage string, pageSize int) ResourceIterator {
+	ctx = withOperation(ctx, "IterateStageResources")
+	return r.IterateResources(ctx, NewStageScope(project, stage), pageSize)
+}
+
+// GetAllProjectResources returns a list of all project resources.
+func (r *ResourceHandler) GetAllProjectResources(project string) ([]*models.Resource, error) {
+	return r.GetAllProjectResourcesWithContext(context.Background(), project)
+}
+
+// GetAllProjectResourcesWithContext returns a list of all project resources.
+func (r *ResourceHandler) GetAllProjectResourcesWithContext(ctx context.Context, project string) ([]*models.Resource, error) {
+	ctx = withOperation(ctx, "GetAllProjectResources")
+	return r.GetAllResourcesWithContext(ctx, NewProjectScope(project))
+}
+
+// GetAllServiceResources returns a list of all service resources.
+func (r *ResourceHandler) GetAllServiceResources(project string, stage string, service string) ([]*models.Resource, error) {
+	return r.GetAllServiceResourcesWithContext(context.Background(), project, stage, service)
+}
+
+// GetAllServiceResourcesWithContext returns a list of all service resources.
+func (r *ResourceHandler) GetAllServiceResourcesWithContext(ctx context.Context, project string, stage string, service string) ([]*models.Resource, error) {
+	ctx = withOperation(ctx, "GetAllServiceResources")
+	return r.GetAllResourcesWithContext(ctx, NewServiceScope(project, stage, service))
+}
+
+// GetAllResourcesWithContext returns a list of all resources of the scope.
+func (r *ResourceHandler) GetAllResourcesWithContext(ctx context.Context, scope ResourceScope) ([]*models.Resource, error) {
+	ctx = withOperation(ctx, "GetAllResources")
+	resources := []*models.Resource{}
+	it := r.IterateResources(ctx, scope, 0)
+	for it.Next() {
+		resources = append(resources, it.Resource())
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
 	}
+	return resources, nil
+}
 
-	return version.Version, nil
+// IterateProjectResources returns an iterator over all resources of a project, which fetches pages of the provided size on demand.
+// If pageSize is 0, the page size of the configuration service is used.
+func (r *ResourceHandler) IterateProjectResources(ctx context.Context, project string, pageSize int) ResourceIterator {
+	ctx = withOperation(ctx, "IterateProjectResources")
+	return r.IterateResources(ctx, NewProjectScope(project), pageSize)
 }
 
-func (r *ResourceHandler) getResource(uri string) (*models.Resource, error) {
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	req, err := http.NewRequest("GET", uri, nil)
-	req.Header.Set("Content-Type", "application/json")
-	addAuthHeader(req, r)
+// IterateServiceResources returns an iterator over all resources of a service, which fetches pages of the provided size on demand.
+// If pageSize is 0, the page size of the configuration service is used.
+func (r *ResourceHandler) IterateServiceResources(ctx context.Context, project string, stage string, service string, pageSize int) ResourceIterator {
+	ctx = withOperation(ctx, "IterateServiceResources")
+	return r.IterateResources(ctx, NewServiceScope(project, stage, service), pageSize)
+}
 
-	resp, err := r.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
+// IterateResources returns an iterator over all resources of the scope, which fetches pages of the provided size on demand.
+// If pageSize is 0, the page size of the configuration service is used.
+func (r *ResourceHandler) IterateResources(ctx context.Context, scope ResourceScope, pageSize int) ResourceIterator {
+	ctx = withOperation(ctx, "IterateResources")
+	return &resourceIterator{pager: newPager(ctx, r, r.Scheme+"://"+r.getBaseURL()+scope.path(), pageSize)}
+}
+
+// GetResourceURIs returns the URIs and versions of all resources of the scope without their content.
+// The configuration service has no metadata-only listing, so the content of all resources is downloaded
+// and discarded page by page. This keeps the memory low, but not the transferred data.
+func (r *ResourceHandler) GetResourceURIs(scope ResourceScope) ([]*models.VersionedResource, error) {
+	return r.GetResourceURIsWithContext(context.Background(), scope)
+}
+
+// GetResourceURIsWithContext returns the URIs and versions of all resources of the scope without their content.
+// The configuration service has no metadata-only listing, so the content of all resources is downloaded
+// and discarded page by page. This keeps the memory low, but not the transferred data.
+func (r *ResourceHandler) GetResourceURIsWithContext(ctx context.Context, scope ResourceScope) ([]*models.VersionedResource, error) {
+	ctx = withOperation(ctx, "GetResourceURIs")
+	resources := []*models.VersionedResource{}
+	it := r.IterateResourceURIs(ctx, scope, 0)
+	for it.Next() {
+		resources = append(resources, it.VersionedResource())
 	}
-	defer resp.Body.Close()
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return resources, nil
+}
+
+// IterateResourceURIs returns an iterator over the URIs and versions of all resources of the scope.
+// The content is downloaded with every page, since the configuration service cannot list resources without it,
+// and dropped as soon as the page has been received.
+func (r *ResourceHandler) IterateResourceURIs(ctx context.Context, scope ResourceScope, pageSize int) ResourceIterator {
+	ctx = withOperation(ctx, "IterateResourceURIs")
+	it := &resourceIterator{pager: newPager(ctx, r, r.Scheme+"://"+r.getBaseURL()+scope.path(), pageSize)}
+	it.dropContent = true
+	return it
+}
+
+// GetProjectResourceAtVersion retrieves a project resource as of the provided version (commit ID)
+func (r *ResourceHandler) GetProjectResourceAtVersion(project string, resourceURI string, version string) (*models.Resource, error) {
+	return r.GetResourceAtVersionWithContext(withOperation(context.Background(), "GetProjectResourceAtVersion"), NewProjectScope(project), resourceURI, version)
+}
+
+// GetStageResourceAtVersion retrieves a stage resource as of the provided version (commit ID)
+func (r *ResourceHandler) GetStageResourceAtVersion(project string, stage string, resourceURI string, version string) (*models.Resource, error) {
+	return r.GetResourceAtVersionWithContext(withOperation(context.Background(), "GetStageResourceAtVersion"), NewStageScope(project, stage), resourceURI, version)
+}
 
-	body, _ := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode == 404 {
-		return nil, errors.New("resource not found")
+// GetServiceResourceAtVersion retrieves a service resource as of the provided version (commit ID)
+func (r *ResourceHandler) GetServiceResourceAtVersion(project string, stage string, service string, resourceURI string, version string) (*models.Resource, error) {
+	return r.GetResourceAtVersionWithContext(withOperation(context.Background(), "GetServiceResourceAtVersion"), NewServiceScope(project, stage, service), resourceURI, version)
+}
+
+// GetResourceAtVersionWithContext retrieves a resource of the scope as of the provided version (commit ID).
+// If version is empty, the latest version is returned. If the configuration service does not return the
+// requested version, an error wrapping ErrVersionsNotSupported is returned.
+func (r *ResourceHandler) GetResourceAtVersionWithContext(ctx context.Context, scope ResourceScope, resourceURI string, version string) (*models.Resource, error) {
+	ctx = withOperation(ctx, "GetResourceAtVersion")
+	uri := r.resourceURI(scope, resourceURI)
+	if version == "" {
+		return r.getResource(ctx, uri)
 	}
-	var resource models.Resource
-	err = json.Unmarshal(body, &resource)
+	resource, err := r.getVersionedResource(ctx, uri+"?"+versionParam+"="+url.QueryEscape(version))
 	if err != nil {
 		return nil, err
 	}
-	decodedStr, err := b64.StdEncoding.DecodeString(resource.ResourceContent)
-	if err != nil {
-		return nil, err
+	if resource.Metadata == nil || resource.Metadata.Version != version {
+		return nil, fmt.Errorf("Error when getting resource %s at version %s: %w", resourceURI, version, ErrVersionsNotSupported)
 	}
-	resource.ResourceContent = string(decodedStr)
-	return &resource, nil
+	return &resource.Resource, nil
 }
 
-func (r *ResourceHandler) deleteResource(uri string) error {
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	req, err := http.NewRequest("DELETE", uri, nil)
-	req.Header.Set("Content-Type", "application/json")
-	addAuthHeader(req, r)
+// GetResourceVersions returns the version history of a resource, starting with the latest version.
+// It requires a configuration service with resource versioning, see ErrVersionsNotSupported.
+func (r *ResourceHandler) GetResourceVersions(scope ResourceScope, resourceURI string) ([]*models.Version, error) {
+	return r.GetResourceVersionsWithContext(context.Background(), scope, resourceURI)
+}
 
-	resp, err := r.HTTPClient.Do(req)
-	if err != nil {
-		return err
+// GetResourceVersionsWithContext returns the version history of a resource, starting with the latest version.
+// It requires a configuration service with resource versioning, see ErrVersionsNotSupported.
+func (r *ResourceHandler) GetResourceVersionsWithContext(ctx context.Context, scope ResourceScope, resourceURI string) ([]*models.Version, error) {
+	ctx = withOperation(ctx, "GetResourceVersions")
+	versions := []*models.Version{}
+	it := r.IterateResourceVersions(ctx, scope, resourceURI, 0)
+	for it.Next() {
+		versions = append(versions, it.Version())
 	}
-	defer resp.Body.Close()
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return versions, nil
+}
 
-	return nil
+// IterateResourceVersions returns an iterator over the version history of a resource, which fetches pages of the provided size on demand.
+// If pageSize is 0, the page size of the configuration service is used. It requires a configuration service
+// with resource versioning, see ErrVersionsNotSupported.
+func (r *ResourceHandler) IterateResourceVersions(ctx context.Context, scope ResourceScope, resourceURI string, pageSize int) VersionIterator {
+	ctx = withOperation(ctx, "IterateResourceVersions")
+	return &versionIterator{pager: newPager(ctx, r, r.resourceURI(scope, resourceURI)+"/version", pageSize)}
 }
 
-// GetAllStageResources returns a list of all resources.
-func (r *ResourceHandler) GetAllStageResources(project string, stage string) ([]*models.Resource, error) {
+// resourceURI returns the URI of a single resource of the scope
+func (r *ResourceHandler) resourceURI(scope ResourceScope, resourceURI string) string {
+	return r.Scheme + "://" + r.getBaseURL() + scope.path() + "/" + url.QueryEscape(resourceURI)
+}
 
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	resources := []*models.Resource{}
+// UpdateProjectResourceIfVersion updates a project resource if its stored version equals expectedVersion.
+// Otherwise, an *APIError for which IsConflict returns true is returned. See UpdateResourceIfVersionWithContext
+// for the requirements on the configuration service.
+func (r *ResourceHandler) UpdateProjectResourceIfVersion(project string, resource *models.Resource, expectedVersion string) (string, error) {
+	return r.UpdateResourceIfVersionWithContext(withOperation(context.Background(), "UpdateProjectResourceIfVersion"), NewProjectScope(project), resource, expectedVersion)
+}
 
-	nextPageKey := ""
+// UpdateStageResourceIfVersion updates a stage resource if its stored version equals expectedVersion.
+// Otherwise, an *APIError for which IsConflict returns true is returned. See UpdateResourceIfVersionWithContext
+// for the requirements on the configuration service.
+func (r *ResourceHandler) UpdateStageResourceIfVersion(project string, stage string, resource *models.Resource, expectedVersion string) (string, error) {
+	return r.UpdateResourceIfVersionWithContext(withOperation(context.Background(), "UpdateStageResourceIfVersion"), NewStageScope(project, stage), resource, expectedVersion)
+}
 
-	for {
-		url, err := url.Parse(r.Scheme + "://" + r.getBaseURL() + "/v1/project/" + project + "/stage/" + stage + "/resource")
-		if err != nil {
-			return nil, err
-		}
-		q := url.Query()
-		if nextPageKey != "" {
-			q.Set("nextPageKey", nextPageKey)
+// UpdateServiceResourceIfVersion updates a service resource if its stored version equals expectedVersion.
+// Otherwise, an *APIError for which IsConflict returns true is returned. See UpdateResourceIfVersionWithContext
+// for the requirements on the configuration service.
+func (r *ResourceHandler) UpdateServiceResourceIfVersion(project string, stage string, service string, resource *models.Resource, expectedVersion string) (string, error) {
+	return r.UpdateResourceIfVersionWithContext(withOperation(context.Background(), "UpdateServiceResourceIfVersion"), NewServiceScope(project, stage, service), resource, expectedVersion)
+}
+
+// UpdateResourceIfVersionWithContext updates a resource of the scope if its stored version equals expectedVersion.
+// Otherwise, an *APIError for which IsConflict returns true is returned. If expectedVersion is empty,
+// the resource is updated unconditionally.
+// The condition is sent as If-Match header. Since configuration services without resource versioning ignore it,
+// the version history is checked after writing: if the new version does not follow expectedVersion, an error
+// wrapping ErrVersionsNotSupported is returned, although the resource has been written.
+func (r *ResourceHandler) UpdateResourceIfVersionWithContext(ctx context.Context, scope ResourceScope, resource *models.Resource, expectedVersion string) (string, error) {
+	ctx = withOperation(ctx, "UpdateResourceIfVersion")
+	version, err := r.writeResource(ctx, r.resourceURI(scope, *resource.ResourceURI), "PUT", resource, expectedVersion)
+	if err != nil || expectedVersion == "" {
+		return version, err
+	}
+	if err := r.checkVersionFollows(ctx, scope, *resource.ResourceURI, version, expectedVersion); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// checkVersionFollows checks that version directly follows expectedVersion in the history of a resource.
+// Newer versions written in the meantime are skipped.
+func (r *ResourceHandler) checkVersionFollows(ctx context.Context, scope ResourceScope, resourceURI string, version string, expectedVersion string) error {
+	it := r.IterateResourceVersions(ctx, scope, resourceURI, 0)
+	found := false
+	for it.Next() {
+		current := it.Version().Version
+		if found {
+			if current == expectedVersion {
+				return nil
+			}
+			break
 		}
-		req, err := http.NewRequest("GET", url.String(), nil)
-		req.Header.Set("Content-Type", "application/json")
-		addAuthHeader(req, r)
+		found = current == version
+	}
+	if it.Err() != nil {
+		return fmt.Errorf("Error when checking version %s of resource %s: %s: %w", version, resourceURI, it.Err().Error(), ErrVersionsNotSupported)
+	}
+	return fmt.Errorf("Error when checking version %s of resource %s: it does not follow version %s: %w", version, resourceURI, expectedVersion, ErrVersionsNotSupported)
+}
 
-		resp, err := r.HTTPClient.Do(req)
+// ModifyResource reads a resource of the scope, passes it to modify and writes back the returned resource
+// if the resource has not been changed in the meantime. On a conflict, the resource is read again and
+// modify is called with the new content. It returns the version of the written resource. If the configuration
+// service does not return the version of the resource, an error is returned instead of writing it unconditionally.
+// See UpdateResourceIfVersionWithContext for the requirements on the configuration service.
+func (r *ResourceHandler) ModifyResource(scope ResourceScope, resourceURI string, modify func(old *models.Resource) (*models.Resource, error)) (string, error) {
+	return r.ModifyResourceWithContext(context.Background(), scope, resourceURI, modify)
+}
+
+// ModifyResourceWithContext reads a resource of the scope, passes it to modify and writes back the returned resource
+// if the resource has not been changed in the meantime. On a conflict, the resource is read again and
+// modify is called with the new content. It returns the version of the written resource. If the configuration
+// service does not return the version of the resource, an error is returned instead of writing it unconditionally.
+// See UpdateResourceIfVersionWithContext for the requirements on the configuration service.
+func (r *ResourceHandler) ModifyResourceWithContext(ctx context.Context, scope ResourceScope, resourceURI string, modify func(old *models.Resource) (*models.Resource, error)) (string, error) {
+	ctx = withOperation(ctx, "ModifyResource")
+	var err error
+	for attempt := 0; attempt < maxModifyAttempts; attempt++ {
+		var old *models.VersionedResource
+		old, err = r.getVersionedResource(ctx, r.resourceURI(scope, resourceURI))
 		if err != nil {
-			return nil, err
+			return "", err
+		}
+		if old.Metadata == nil || old.Metadata.Version == "" {
+			return "", fmt.Errorf("Error when modifying resource %s: the configuration service did not return its version", resourceURI)
 		}
-		defer resp.Body.Close()
 
-		body, err := ioutil.ReadAll(resp.Body)
+		var modified *models.Resource
+		modified, err = modify(&old.Resource)
 		if err != nil {
-			return nil, err
+			return "", err
+		}
+		if modified.ResourceURI == nil {
+			modified.ResourceURI = &resourceURI
 		}
 
-		if resp.StatusCode == 200 {
-			var received models.Resources
-			err = json.Unmarshal(body, &received)
-			if err != nil {
-				return nil, err
-			}
-			resources = append(resources, received.Resources...)
-
-			if received.NextPageKey == "" || received.NextPageKey == "0" {
-				break
-			}
-			nextPageKey = received.NextPageKey
-		} else {
-			var respErr models.Error
-			err = json.Unmarshal(body, &respErr)
-			if err != nil {
-				return nil, err
-			}
-			return nil, errors.New("Response Error Code: " + string(respErr.Code) + " Message: " + *respErr.Message)
+		var version string
+		version, err = r.UpdateResourceIfVersionWithContext(ctx, scope, modified, old.Metadata.Version)
+		if err == nil || !IsConflictError(err) {
+			return version, err
 		}
 	}
-
-	return resources, nil
+	return "", err
 }
@@ -0,0 +1,214 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+)
+
+func TestGetResourceAtVersion(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	v1 := srv.SetResource("sockshop", "dev", "carts", "values.yaml", []byte("replicas: 1"))
+	v2 := srv.SetResource("sockshop", "dev", "carts", "values.yaml", []byte("replicas: 2"))
+	resourceHandler := utils.NewResourceHandler(srv.URL)
+
+	tests := []struct {
+		name        string
+		version     string
+		wantContent string
+		wantErr     bool
+	}{
+		{name: "latest version", version: "", wantContent: "replicas: 2"},
+		{name: "current version", version: v2, wantContent: "replicas: 2"},
+		{name: "earlier version", version: v1, wantContent: "replicas: 1"},
+		{name: "unknown version", version: "0000000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := resourceHandler.GetServiceResourceAtVersion("sockshop", "dev", "carts", "values.yaml", tt.version)
+			if tt.wantErr {
+				if !utils.IsNotFoundError(err) {
+					t.Errorf("got error %v, want not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resource.ResourceContent != tt.wantContent {
+				t.Errorf("got content %q, want %q", resource.ResourceContent, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestGetResourceAtVersionWithoutVersioning(t *testing.T) {
+	// configuration services without resource versioning ignore the version and return the latest content
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"resourceURI":"values.yaml","resourceContent":"cmVwbGljYXM6IDI="}`))
+	}))
+	defer srv.Close()
+
+	_, err := utils.NewResourceHandler(srv.URL).GetServiceResourceAtVersion("sockshop", "dev", "carts", "values.yaml", "a1b2c3d")
+	if !errors.Is(err, utils.ErrVersionsNotSupported) {
+		t.Errorf("got error %v, want ErrVersionsNotSupported", err)
+	}
+}
+
+func TestGetResourceVersions(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 2
+	want := []string{}
+	for _, content := range []string{"a", "b", "c", "d", "e"} {
+		want = append([]string{srv.SetResource("sockshop", "", "", "shipyard.yaml", []byte(content))}, want...)
+	}
+
+	versions, err := utils.NewResourceHandler(srv.URL).GetResourceVersionsWithContext(context.Background(), utils.NewProjectScope("sockshop"), "shipyard.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != len(want) {
+		t.Fatalf("got %d versions, want %d", len(versions), len(want))
+	}
+	for i, version := range versions {
+		if version.Version != want[i] {
+			t.Errorf("got version %s at position %d, want %s", version.Version, i, want[i])
+		}
+	}
+}
+
+func TestGetResourceVersionsOfUnknownResource(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.AddProject("sockshop")
+
+	_, err := utils.NewResourceHandler(srv.URL).GetResourceVersions(utils.NewProjectScope("sockshop"), "shipyard.yaml")
+	if !utils.IsNotFoundError(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+}
+
+func TestUpdateResourceIfVersion(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	v1 := srv.SetResource("sockshop", "dev", "", "shipyard.yaml", []byte("a"))
+	v2 := srv.SetResource("sockshop", "dev", "", "shipyard.yaml", []byte("b"))
+	resourceHandler := utils.NewResourceHandler(srv.URL)
+
+	tests := []struct {
+		name            string
+		expectedVersion string
+		wantConflict    bool
+	}{
+		{name: "stale version", expectedVersion: v1, wantConflict: true},
+		{name: "current version", expectedVersion: v2},
+		{name: "no version", expectedVersion: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := "shipyard.yaml"
+			content := "written with " + tt.name
+			_, err := resourceHandler.UpdateStageResourceIfVersion("sockshop", "dev", &models.Resource{ResourceURI: &uri, ResourceContent: content}, tt.expectedVersion)
+			if tt.wantConflict {
+				if !utils.IsConflictError(err) {
+					t.Errorf("got error %v, want conflict", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stored, _ := srv.GetResource("sockshop", "dev", "", uri); string(stored) != content {
+				t.Errorf("got stored content %q, want %q", stored, content)
+			}
+		})
+	}
+}
+
+func TestUpdateResourceIfVersionWithoutVersioning(t *testing.T) {
+	tests := []struct {
+		name    string
+		history string
+	}{
+		{name: "no version history", history: ""},
+		{name: "other previous version", history: `{"versions":[{"version":"v3"},{"version":"v2"}]}`},
+		{name: "new version missing", history: `{"versions":[{"version":"v2"},{"version":"v1"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the server ignores If-Match and writes the resource unconditionally
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "PUT":
+					w.Write([]byte(`{"version":"v3"}`))
+				case tt.history != "":
+					w.Write([]byte(tt.history))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			uri := "shipyard.yaml"
+			_, err := utils.NewResourceHandler(srv.URL).UpdateProjectResourceIfVersion("sockshop", &models.Resource{ResourceURI: &uri, ResourceContent: "b"}, "v1")
+			if !errors.Is(err, utils.ErrVersionsNotSupported) {
+				t.Errorf("got error %v, want ErrVersionsNotSupported", err)
+			}
+		})
+	}
+}
+
+func TestModifyResource(t *testing.T) {
+	srv := configservicetest.NewServer()
+	defer srv.Close()
+	srv.SetResource("sockshop", "", "", "counter", []byte("1"))
+	scope := utils.NewProjectScope("sockshop")
+
+	calls := 0
+	_, err := utils.NewResourceHandler(srv.URL).ModifyResource(scope, "counter", func(old *models.Resource) (*models.Resource, error) {
+		calls++
+		if calls == 1 {
+			// a concurrent writer changes the resource after it has been read
+			srv.SetResource("sockshop", "", "", "counter", []byte("5"))
+		}
+		return &models.Resource{ResourceContent: old.ResourceContent + "0"}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls of modify, want 2", calls)
+	}
+	if stored, _ := srv.GetResource("sockshop", "", "", "counter"); string(stored) != "50" {
+		t.Errorf("got stored content %q, want %q", stored, "50")
+	}
+}
+
+func TestModifyResourceWithoutVersion(t *testing.T) {
+	writes := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			writes++
+		}
+		w.Write([]byte(`{"resourceURI":"counter","resourceContent":"MQ=="}`))
+	}))
+	defer srv.Close()
+
+	_, err := utils.NewResourceHandler(srv.URL).ModifyResource(utils.NewProjectScope("sockshop"), "counter", func(old *models.Resource) (*models.Resource, error) {
+		return old, nil
+	})
+	if err == nil {
+		t.Error("got no error, want an error for the missing version")
+	}
+	if writes != 0 {
+		t.Errorf("got %d writes, want none", writes)
+	}
+}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy describes if and how failed requests to the configuration service are retried.
+// A handler without a RetryPolicy sends every request exactly once.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one
+	MaxAttempts int
+	// InitialBackoff is the time waited before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff limits the time waited between two attempts
+	MaxBackoff time.Duration
+	// Multiplier is applied to the backoff after each attempt
+	Multiplier float64
+	// Jitter is the fraction (between 0 and 1) by which each backoff is randomly reduced
+	Jitter float64
+	// RetryableStatusCodes contains the status codes which cause a request to be retried
+	RetryableStatusCodes []int
+	// RetryNetworkErrors indicates whether requests failing without a response are retried
+	RetryNetworkErrors bool
+	// RetryableMethods contains the HTTP methods which may be retried.
+	// If empty, only idempotent methods are retried.
+	RetryableMethods []string
+	// IgnoreRetryAfter disables waiting for the duration given in a Retry-After header
+	IgnoreRetryAfter bool
+}
+
+var idempotentMethods = []string{"GET", "HEAD", "OPTIONS", "PUT", "DELETE"}
+
+// NewDefaultRetryPolicy returns a RetryPolicy which retries idempotent requests up to
+// five times if the configuration service is unavailable or cannot be reached
+func NewDefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) isRetryableMethod(method string) bool {
+	methods := p.RetryableMethods
+	if len(methods) == 0 {
+		methods = idempotentMethods
+	}
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) isRetryableStatusCode(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry decides whether the attempt-th attempt is followed by another one
+func (p *RetryPolicy) shouldRetry(attempt int, method string, resp *http.Response, err error) bool {
+	if attempt >= p.maxAttempts() || !p.isRetryableMethod(method) {
+		return false
+	}
+	if err != nil {
+		return p.RetryNetworkErrors
+	}
+	return p.isRetryableStatusCode(resp.StatusCode)
+}
+
+// backoff returns the time to wait after the attempt-th attempt
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil && !p.IgnoreRetryAfter {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return retryAfter
+		}
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	wait := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		wait -= wait * math.Min(p.Jitter, 1) * rand.Float64()
+	}
+	return time.Duration(wait)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,146 @@
+package utils_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+)
+
+func newRetryingProjectHandler(srv *configservicetest.Server, policy *utils.RetryPolicy) *utils.ProjectHandler {
+	projectHandler := utils.NewProjectHandler(srv.URL)
+	projectHandler.RetryPolicy = policy
+	return projectHandler
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       *utils.RetryPolicy
+		fault        configservicetest.Fault
+		create       bool
+		wantErr      bool
+		wantRequests int
+	}{
+		{
+			name:         "no policy sends the request once",
+			fault:        configservicetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 1},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:         "retryable status code is retried until it succeeds",
+			policy:       &utils.RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusServiceUnavailable}},
+			fault:        configservicetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 2},
+			wantRequests: 3,
+		},
+		{
+			name:         "attempts are limited by MaxAttempts",
+			policy:       &utils.RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusServiceUnavailable}},
+			fault:        configservicetest.Fault{StatusCode: http.StatusServiceUnavailable},
+			wantErr:      true,
+			wantRequests: 3,
+		},
+		{
+			name:         "other status codes are not retried",
+			policy:       &utils.RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusServiceUnavailable}},
+			fault:        configservicetest.Fault{StatusCode: http.StatusInternalServerError, Times: 1},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:         "POST is not retried by default",
+			policy:       &utils.RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusServiceUnavailable}},
+			fault:        configservicetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 1},
+			create:       true,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name: "POST is retried if it is a retryable method",
+			policy: &utils.RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+				RetryableMethods: []string{"POST"}},
+			fault:        configservicetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 1},
+			create:       true,
+			wantRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := configservicetest.NewServer()
+			defer srv.Close()
+			srv.AddProject("sockshop")
+			srv.InjectFault(tt.fault)
+			projectHandler := newRetryingProjectHandler(srv, tt.policy)
+
+			var err error
+			if tt.create {
+				err = projectHandler.CreateProject(models.Project{ProjectName: "carts"})
+			} else {
+				_, err = projectHandler.GetProject(models.Project{ProjectName: "sockshop"})
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if srv.RequestCount() != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", srv.RequestCount(), tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *utils.RetryPolicy
+		minWait time.Duration
+		maxWait time.Duration
+	}{
+		{
+			name:    "Retry-After is waited instead of the backoff",
+			policy:  &utils.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, RetryableStatusCodes: []int{http.StatusTooManyRequests}},
+			minWait: time.Second,
+			maxWait: 5 * time.Second,
+		},
+		{
+			name: "Retry-After is limited by MaxBackoff",
+			policy: &utils.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond,
+				RetryableStatusCodes: []int{http.StatusTooManyRequests}},
+			maxWait: 500 * time.Millisecond,
+		},
+		{
+			name: "Retry-After is ignored",
+			policy: &utils.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, IgnoreRetryAfter: true,
+				RetryableStatusCodes: []int{http.StatusTooManyRequests}},
+			maxWait: 500 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := configservicetest.NewServer()
+			defer srv.Close()
+			srv.AddProject("sockshop")
+			srv.InjectFault(configservicetest.Fault{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"1"}},
+				Times:      1,
+			})
+			projectHandler := newRetryingProjectHandler(srv, tt.policy)
+
+			start := time.Now()
+			if _, err := projectHandler.GetProject(models.Project{ProjectName: "sockshop"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			elapsed := time.Since(start)
+			if elapsed < tt.minWait || elapsed > tt.maxWait {
+				t.Errorf("request took %s, want between %s and %s", elapsed, tt.minWait, tt.maxWait)
+			}
+			if srv.RequestCount() != 2 {
+				t.Errorf("got %d requests, want 2", srv.RequestCount())
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/models"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// URIs of the service level files
+const (
+	ServiceIndicatorsURI = "service-indicators.yaml"
+	ServiceObjectivesURI = "service-objectives.yaml"
+	RemediationURI       = "remediation.yaml"
+)
+
+// configFile is a YAML resource loaded from a level of the configuration service
+type configFile struct {
+	// resource is the path of the resource used in validation errors
+	resource string
+	// content is the YAML document
+	content []byte
+	// root is the top-level mapping of the document. It is nil if the document is empty.
+	root map[interface{}]interface{}
+}
+
+// errorf returns a validation error of the file. Problems found after decoding the document do not refer to a line,
+// so the message has to identify the entry.
+func (f *configFile) errorf(format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Resource: f.resource, Message: fmt.Sprintf(format, args...)}
+}
+
+// has returns whether the top-level mapping of the document contains the key
+func (f *configFile) has(key string) bool {
+	_, ok := f.root[key]
+	return ok
+}
+
+// decode decodes the document into v and returns type errors as validation errors
+func (f *configFile) decode(v interface{}) ValidationErrors {
+	if f.root == nil {
+		return nil
+	}
+	err := yaml.Unmarshal(f.content, v)
+	if err == nil {
+		return nil
+	}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		problems := ValidationErrors{}
+		for _, msg := range typeErr.Errors {
+			problems = append(problems, f.yamlError(msg))
+		}
+		return problems
+	}
+	return ValidationErrors{f.yamlError(err.Error())}
+}
+
+var yamlLineRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+
+// yamlError converts an error message of the YAML parser to a validation error
+func (f *configFile) yamlError(msg string) *ValidationError {
+	problem := &ValidationError{Resource: f.resource, Message: msg}
+	if match := yamlLineRegex.FindStringSubmatch(msg); match != nil {
+		problem.Line, _ = strconv.Atoi(match[1])
+		problem.Message = msg[len(match[0]):]
+	}
+	return problem
+}
+
+// loadConfigFiles loads a YAML resource from the project, the stage and the service, in this order of precedence.
+// Levels are skipped if stage or service is empty or if the resource does not exist. Syntax errors are returned as
+// validation errors. If the resource does not exist on any level, the error of the most specific level is returned.
+func (k *KeptnHandler) loadConfigFiles(ctx context.Context, project string, stage string, service string, resourceURI string) ([]*configFile, ValidationErrors, error) {
+	type level struct {
+		path string
+		get  func() (*models.Resource, error)
+	}
+	levels := []level{{
+		path: project,
+		get: func() (*models.Resource, error) {
+			return k.ResourceHandler.GetProjectResourceWithContext(ctx, project, resourceURI)
+		},
+	}}
+	if stage != "" {
+		levels = append(levels, level{
+			path: path.Join(project, stage),
+			get: func() (*models.Resource, error) {
+				return k.ResourceHandler.GetStageResourceWithContext(ctx, project, stage, resourceURI)
+			},
+		})
+		if service != "" {
+			levels = append(levels, level{
+				path: path.Join(project, stage, service),
+				get: func() (*models.Resource, error) {
+					return k.ResourceHandler.GetServiceResourceWithContext(ctx, project, stage, service, resourceURI)
+				},
+			})
+		}
+	}
+
+	files := []*configFile{}
+	problems := ValidationErrors{}
+	var notFoundErr error
+	for _, l := range levels {
+		resource, err := l.get()
+		if IsNotFoundError(err) {
+			notFoundErr = err
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		file := &configFile{resource: path.Join(l.path, resourceURI), content: []byte(resource.ResourceContent)}
+		var doc interface{}
+		if err := yaml.Unmarshal(file.content, &doc); err != nil {
+			problems = append(problems, file.yamlError(err.Error()))
+			continue
+		}
+		if doc != nil {
+			root, ok := doc.(map[interface{}]interface{})
+			if !ok {
+				problems = append(problems, file.errorf("expected a mapping at the top level"))
+				continue
+			}
+			file.root = root
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 && len(problems) == 0 {
+		return nil, nil, notFoundErr
+	}
+	return files, problems, nil
+}
+
+// GetServiceIndicators returns the service indicators of a service. The service-indicators.yaml files of the project,
+// the stage and the service are merged, with indicators of more specific levels replacing those with the same metric.
+// Invalid files are reported as ValidationErrors.
+func (k *KeptnHandler) GetServiceIndicators(project string, stage string, service string) (*models.ServiceIndicators, error) {
+	return k.GetServiceIndicatorsWithContext(context.Background(), project, stage, service)
+}
+
+// GetServiceIndicatorsWithContext returns the merged and validated service indicators of a service
+func (k *KeptnHandler) GetServiceIndicatorsWithContext(ctx context.Context, project string, stage string, service string) (*models.ServiceIndicators, error) {
+	indicators, _, err := k.loadServiceIndicators(ctx, project, stage, service)
+	if err != nil {
+		return nil, err
+	}
+	return indicators, nil
+}
+
+func (k *KeptnHandler) loadServiceIndicators(ctx context.Context, project string, stage string, service string) (*models.ServiceIndicators, ValidationErrors, error) {
+	files, problems, err := k.loadConfigFiles(ctx, project, stage, service, ServiceIndicatorsURI)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged := &models.ServiceIndicators{Indicators: []*models.ServiceIndicator{}}
+	positions := map[string]int{}
+	for _, file := range files {
+		indicators := &models.ServiceIndicators{}
+		if decodeProblems := file.decode(indicators); len(decodeProblems) > 0 {
+			problems = append(problems, decodeProblems...)
+			continue
+		}
+		defined := map[string]bool{}
+		for i, indicator := range indicators.Indicators {
+			switch {
+			case indicator.Metric == "":
+				problems = append(problems, file.errorf("indicator %d has no metric", i+1))
+				continue
+			case defined[indicator.Metric]:
+				problems = append(problems, file.errorf("indicator %s is defined more than once", indicator.Metric))
+				continue
+			}
+			defined[indicator.Metric] = true
+			if indicator.Source == "" {
+				problems = append(problems, file.errorf("indicator %s has no source", indicator.Metric))
+			}
+
+			if pos, ok := positions[indicator.Metric]; ok {
+				merged.Indicators[pos] = indicator
+			} else {
+				positions[indicator.Metric] = len(merged.Indicators)
+				merged.Indicators = append(merged.Indicators, indicator)
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems, problems
+	}
+	return merged, nil, nil
+}
+
+// GetServiceObjectives returns the service objectives of a service. The service-objectives.yaml files of the project,
+// the stage and the service are merged: objectives of more specific levels replace those with the same metric, and
+// pass, warning and comparison are taken from the most specific level defining them. The objectives must refer to
+// indicators defined in the service-indicators.yaml files, their scores must add up to 100 and their timeframes and
+// criteria must be valid. Invalid files are reported as ValidationErrors.
+func (k *KeptnHandler) GetServiceObjectives(project string, stage string, service string) (*models.ServiceObjectives, error) {
+	return k.GetServiceObjectivesWithContext(context.Background(), project, stage, service)
+}
+
+// GetServiceObjectivesWithContext returns the merged and validated service objectives of a service
+func (k *KeptnHandler) GetServiceObjectivesWithContext(ctx context.Context, project string, stage string, service string) (*models.ServiceObjectives, error) {
+	files, problems, err := k.loadConfigFiles(ctx, project, stage, service, ServiceObjectivesURI)
+	if err != nil {
+		return nil, err
+	}
+	indicators, indicatorProblems, err := k.loadServiceIndicators(ctx, project, stage, service)
+	if err != nil && !IsNotFoundError(err) && len(indicatorProblems) == 0 {
+		return nil, err
+	}
+	problems = append(problems, indicatorProblems...)
+
+	merged := &models.ServiceObjectives{Objectives: []*models.ServiceObjective{}}
+	positions := map[string]int{}
+	// the files defining the objectives and thresholds which are reported in problems of the merged objectives
+	objectiveFiles := map[string]*configFile{}
+	var thresholdsFile, objectivesFile *configFile
+	for _, file := range files {
+		objectives := &models.ServiceObjectives{}
+		if decodeProblems := file.decode(objectives); len(decodeProblems) > 0 {
+			problems = append(problems, decodeProblems...)
+			continue
+		}
+
+		for _, key := range []string{"pass", "warning"} {
+			if !file.has(key) {
+				continue
+			}
+			value := objectives.Pass
+			if key == "warning" {
+				value = objectives.Warning
+				merged.Warning = value
+			} else {
+				merged.Pass = value
+			}
+			if value < 0 || value > 100 {
+				problems = append(problems, file.errorf("%s must be a percentage between 0 and 100", key))
+			}
+			thresholdsFile = file
+		}
+		if file.has("comparison") {
+			merged.Comparison = objectives.Comparison
+			if objectives.Comparison != nil && objectives.Comparison.NumberOfComparisonResults < 1 {
+				problems = append(problems, file.errorf("numberOfComparisonResults must be at least 1"))
+			}
+		}
+		if file.has("objectives") {
+			objectivesFile = file
+		}
+
+		defined := map[string]bool{}
+		for i, objective := range objectives.Objectives {
+			switch {
+			case objective.Metric == "":
+				problems = append(problems, file.errorf("objective %d has no metric", i+1))
+				continue
+			case defined[objective.Metric]:
+				problems = append(problems, file.errorf("objective %s is defined more than once", objective.Metric))
+				continue
+			}
+			defined[objective.Metric] = true
+
+			if objective.Timeframe != "" {
+				if _, err := time.ParseDuration(objective.Timeframe); err != nil {
+					problems = append(problems, file.errorf("objective %s has invalid timeframe '%s'", objective.Metric, objective.Timeframe))
+				}
+			}
+			for _, c := range objective.Criteria {
+				if _, err := models.ParseCriterion(c); err != nil {
+					problems = append(problems, file.errorf("objective %s has %s", objective.Metric, err.Error()))
+				}
+			}
+			if objective.Score < 0 {
+				problems = append(problems, file.errorf("objective %s has a negative score", objective.Metric))
+			}
+
+			objectiveFiles[objective.Metric] = file
+			if pos, ok := positions[objective.Metric]; ok {
+				merged.Objectives[pos] = objective
+			} else {
+				positions[objective.Metric] = len(merged.Objectives)
+				merged.Objectives = append(merged.Objectives, objective)
+			}
+		}
+	}
+
+	defined := map[string]bool{}
+	if indicators != nil {
+		for _, indicator := range indicators.Indicators {
+			defined[indicator.Metric] = true
+		}
+	}
+	var scores float64
+	for _, objective := range merged.Objectives {
+		scores += float64(objective.Score)
+		if !defined[objective.Metric] && len(indicatorProblems) == 0 {
+			problems = append(problems, objectiveFiles[objective.Metric].errorf("objective %s refers to an undefined indicator", objective.Metric))
+		}
+	}
+	if len(merged.Objectives) > 0 && math.Abs(scores-100) > 1e-3 && objectivesFile != nil {
+		problems = append(problems, objectivesFile.errorf("the scores of the objectives add up to %s instead of 100", strconv.FormatFloat(scores, 'g', 6, 64)))
+	}
+	if merged.Warning > merged.Pass && thresholdsFile != nil {
+		problems = append(problems, thresholdsFile.errorf("warning %d exceeds pass %d", merged.Warning, merged.Pass))
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return merged, nil
+}
+
+// GetRemediations returns the remediations of a service. The remediation.yaml files of the project, the stage and
+// the service are merged, with remediations of more specific levels replacing those with the same name.
+// Invalid files are reported as ValidationErrors.
+func (k *KeptnHandler) GetRemediations(project string, stage string, service string) (*models.Remediations, error) {
+	return k.GetRemediationsWithContext(context.Background(), project, stage, service)
+}
+
+// GetRemediationsWithContext returns the merged and validated remediations of a service
+func (k *KeptnHandler) GetRemediationsWithContext(ctx context.Context, project string, stage string, service string) (*models.Remediations, error) {
+	files, problems, err := k.loadConfigFiles(ctx, project, stage, service, RemediationURI)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &models.Remediations{Remediations: []*models.Remediation{}}
+	positions := map[string]int{}
+	for _, file := range files {
+		remediations := &models.Remediations{}
+		if decodeProblems := file.decode(remediations); len(decodeProblems) > 0 {
+			problems = append(problems, decodeProblems...)
+			continue
+		}
+		defined := map[string]bool{}
+		for i, remediation := range remediations.Remediations {
+			switch {
+			case remediation.Name == "":
+				problems = append(problems, file.errorf("remediation %d has no name", i+1))
+				continue
+			case defined[remediation.Name]:
+				problems = append(problems, file.errorf("remediation %s is defined more than once", remediation.Name))
+				continue
+			}
+			defined[remediation.Name] = true
+
+			if len(remediation.Actions) == 0 {
+				problems = append(problems, file.errorf("remediation %s has no actions", remediation.Name))
+			}
+			for j, action := range remediation.Actions {
+				if action.Action == "" {
+					problems = append(problems, file.errorf("action %d of remediation %s has no action", j+1, remediation.Name))
+				}
+			}
+
+			if pos, ok := positions[remediation.Name]; ok {
+				merged.Remediations[pos] = remediation
+			} else {
+				positions[remediation.Name] = len(merged.Remediations)
+				merged.Remediations = append(merged.Remediations, remediation)
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return merged, nil
+}
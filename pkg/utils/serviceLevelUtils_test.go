@@ -0,0 +1,309 @@
+package utils_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/models"
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/go-utils/pkg/utils/configservicetest"
+)
+
+// newServiceLevelServer returns a configuration service with service level files on the project, stage and service level
+func newServiceLevelServer() *configservicetest.Server {
+	srv := configservicetest.NewServer()
+	srv.SetResource("sockshop", "", "", utils.ServiceIndicatorsURI, []byte(`indicators:
+- metric: request_latency_seconds
+  source: prometheus
+  query: project-latency
+- metric: error_rate
+  source: prometheus
+  query: project-errors
+`))
+	srv.SetResource("sockshop", "dev", "", utils.ServiceIndicatorsURI, []byte(`indicators:
+- metric: error_rate
+  source: prometheus
+  query: stage-errors
+`))
+	srv.SetResource("sockshop", "dev", "carts", utils.ServiceIndicatorsURI, []byte(`indicators:
+- metric: request_latency_seconds
+  source: dynatrace
+  query: service-latency
+- metric: throughput
+  source: prometheus
+  query: service-throughput
+`))
+
+	srv.SetResource("sockshop", "", "", utils.ServiceObjectivesURI, []byte(`pass: 90
+warning: 75
+objectives:
+- metric: request_latency_seconds
+  threshold: 0.8
+  timeframe: 5m
+  score: 50
+- metric: error_rate
+  threshold: 0.01
+  timeframe: 5m
+  score: 50
+`))
+	srv.SetResource("sockshop", "dev", "", utils.ServiceObjectivesURI, []byte(`warning: 60
+comparison:
+  numberOfComparisonResults: 3
+objectives:
+- metric: error_rate
+  criteria: ["<=+10%"]
+  score: 50
+`))
+
+	srv.SetResource("sockshop", "", "", utils.RemediationURI, []byte(`remediations:
+- name: response_time_p90
+  actions:
+  - action: scaling
+    value: "+1"
+- name: failure_rate
+  actions:
+  - action: featuretoggle
+`))
+	srv.SetResource("sockshop", "dev", "carts", utils.RemediationURI, []byte(`remediations:
+- name: failure_rate
+  actions:
+  - action: rollback
+`))
+	return srv
+}
+
+func TestGetServiceIndicators(t *testing.T) {
+	srv := newServiceLevelServer()
+	defer srv.Close()
+	k := utils.NewKeptnHandler(utils.NewResourceHandler(srv.URL))
+
+	tests := []struct {
+		name        string
+		stage       string
+		service     string
+		wantQueries map[string]string
+		wantMetrics []string
+	}{
+		{
+			name:        "project",
+			wantMetrics: []string{"request_latency_seconds", "error_rate"},
+			wantQueries: map[string]string{"request_latency_seconds": "project-latency", "error_rate": "project-errors"},
+		},
+		{
+			name:        "stage",
+			stage:       "dev",
+			wantMetrics: []string{"request_latency_seconds", "error_rate"},
+			wantQueries: map[string]string{"request_latency_seconds": "project-latency", "error_rate": "stage-errors"},
+		},
+		{
+			name:        "service",
+			stage:       "dev",
+			service:     "carts",
+			wantMetrics: []string{"request_latency_seconds", "error_rate", "throughput"},
+			wantQueries: map[string]string{"request_latency_seconds": "service-latency", "error_rate": "stage-errors", "throughput": "service-throughput"},
+		},
+		{
+			// the service is ignored without a stage
+			name:        "service without stage",
+			service:     "carts",
+			wantMetrics: []string{"request_latency_seconds", "error_rate"},
+			wantQueries: map[string]string{"request_latency_seconds": "project-latency", "error_rate": "project-errors"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indicators, err := k.GetServiceIndicators("sockshop", tt.stage, tt.service)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			metrics := []string{}
+			queries := map[string]string{}
+			for _, indicator := range indicators.Indicators {
+				metrics = append(metrics, indicator.Metric)
+				queries[indicator.Metric] = indicator.Query
+			}
+			if !reflect.DeepEqual(metrics, tt.wantMetrics) {
+				t.Errorf("got metrics %v, want %v", metrics, tt.wantMetrics)
+			}
+			if !reflect.DeepEqual(queries, tt.wantQueries) {
+				t.Errorf("got queries %v, want %v", queries, tt.wantQueries)
+			}
+		})
+	}
+}
+
+func TestGetServiceObjectives(t *testing.T) {
+	srv := newServiceLevelServer()
+	defer srv.Close()
+	k := utils.NewKeptnHandler(utils.NewResourceHandler(srv.URL))
+
+	objectives, err := k.GetServiceObjectives("sockshop", "dev", "carts")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// pass is taken from the project, warning and comparison from the stage
+	if objectives.Pass != 90 || objectives.Warning != 60 {
+		t.Errorf("got pass %d and warning %d, want 90 and 60", objectives.Pass, objectives.Warning)
+	}
+	if objectives.Comparison == nil || objectives.Comparison.NumberOfComparisonResults != 3 {
+		t.Errorf("got comparison %+v, want 3 comparison results", objectives.Comparison)
+	}
+	want := []*models.ServiceObjective{
+		{Metric: "request_latency_seconds", Threshold: 0.8, Timeframe: "5m", Score: 50},
+		{Metric: "error_rate", Criteria: []string{"<=+10%"}, Score: 50},
+	}
+	if !reflect.DeepEqual(objectives.Objectives, want) {
+		t.Errorf("got objectives %+v, want %+v", objectives.Objectives, want)
+	}
+}
+
+func TestGetRemediations(t *testing.T) {
+	srv := newServiceLevelServer()
+	defer srv.Close()
+	k := utils.NewKeptnHandler(utils.NewResourceHandler(srv.URL))
+
+	tests := []struct {
+		name        string
+		stage       string
+		service     string
+		wantActions map[string]string
+	}{
+		{name: "project", wantActions: map[string]string{"response_time_p90": "scaling", "failure_rate": "featuretoggle"}},
+		{name: "service", stage: "dev", service: "carts", wantActions: map[string]string{"response_time_p90": "scaling", "failure_rate": "rollback"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remediations, err := k.GetRemediations("sockshop", tt.stage, tt.service)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actions := map[string]string{}
+			for _, remediation := range remediations.Remediations {
+				actions[remediation.Name] = remediation.Actions[0].Action
+			}
+			if !reflect.DeepEqual(actions, tt.wantActions) {
+				t.Errorf("got actions %v, want %v", actions, tt.wantActions)
+			}
+		})
+	}
+
+	if _, err := k.GetRemediations("unknown", "dev", "carts"); !utils.IsNotFoundError(err) {
+		t.Errorf("got error %v for a project without remediations, want not found", err)
+	}
+}
+
+func TestServiceLevelValidationErrors(t *testing.T) {
+	const resource = "sockshop/dev/carts/"
+	tests := []struct {
+		name       string
+		uri        string
+		content    string
+		wantErrors utils.ValidationErrors
+	}{
+		{
+			name: "invalid indicators",
+			uri:  utils.ServiceIndicatorsURI,
+			content: `indicators:
+- source: prometheus
+- metric: throughput
+- metric: throughput
+  source: prometheus
+`,
+			wantErrors: utils.ValidationErrors{
+				{Resource: resource + utils.ServiceIndicatorsURI, Message: "indicator 1 has no metric"},
+				{Resource: resource + utils.ServiceIndicatorsURI, Message: "indicator throughput has no source"},
+				{Resource: resource + utils.ServiceIndicatorsURI, Message: "indicator throughput is defined more than once"},
+			},
+		},
+		{
+			name: "invalid objectives",
+			uri:  utils.ServiceObjectivesURI,
+			content: `pass: 50
+warning: 60
+objectives:
+- score: 10
+- metric: request_latency_seconds
+  timeframe: 5 minutes
+  criteria: ["<5%"]
+  score: 30
+- metric: response_time
+  score: 10
+`,
+			wantErrors: utils.ValidationErrors{
+				{Resource: resource + utils.ServiceObjectivesURI, Message: "objective 1 has no metric"},
+				{Resource: resource + utils.ServiceObjectivesURI, Message: "objective request_latency_seconds has invalid timeframe '5 minutes'"},
+				{Resource: resource + utils.ServiceObjectivesURI, Message: "objective request_latency_seconds has invalid criterion '<5%': a percentage must be signed, e.g. +5%"},
+				{Resource: resource + utils.ServiceObjectivesURI, Message: "objective response_time refers to an undefined indicator"},
+				{Resource: resource + utils.ServiceObjectivesURI, Message: "the scores of the objectives add up to 90 instead of 100"},
+				{Resource: resource + utils.ServiceObjectivesURI, Message: "warning 60 exceeds pass 50"},
+			},
+		},
+		{
+			name:    "type error",
+			uri:     utils.ServiceObjectivesURI,
+			content: "pass: 90\nwarning: abc\n",
+			wantErrors: utils.ValidationErrors{
+				{Resource: resource + utils.ServiceObjectivesURI, Line: 2, Message: "cannot unmarshal !!str `abc` into int"},
+			},
+		},
+		{
+			name:    "syntax error",
+			uri:     utils.RemediationURI,
+			content: "remediations:\n  - name: cpu\n - name: memory\n",
+			wantErrors: utils.ValidationErrors{
+				{Resource: resource + utils.RemediationURI, Line: 2, Message: "did not find expected key"},
+			},
+		},
+		{
+			name:    "no mapping",
+			uri:     utils.RemediationURI,
+			content: "- name: cpu\n",
+			wantErrors: utils.ValidationErrors{
+				{Resource: resource + utils.RemediationURI, Message: "expected a mapping at the top level"},
+			},
+		},
+		{
+			name: "invalid remediations",
+			uri:  utils.RemediationURI,
+			content: `remediations:
+- actions:
+  - action: scaling
+- name: cpu
+- name: memory
+  actions:
+  - action: restart
+  - value: "+1"
+`,
+			wantErrors: utils.ValidationErrors{
+				{Resource: resource + utils.RemediationURI, Message: "remediation 1 has no name"},
+				{Resource: resource + utils.RemediationURI, Message: "remediation cpu has no actions"},
+				{Resource: resource + utils.RemediationURI, Message: "action 2 of remediation memory has no action"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServiceLevelServer()
+			defer srv.Close()
+			srv.SetResource("sockshop", "dev", "carts", tt.uri, []byte(tt.content))
+			k := utils.NewKeptnHandler(utils.NewResourceHandler(srv.URL))
+
+			var err error
+			switch tt.uri {
+			case utils.ServiceIndicatorsURI:
+				_, err = k.GetServiceIndicators("sockshop", "dev", "carts")
+			case utils.ServiceObjectivesURI:
+				_, err = k.GetServiceObjectives("sockshop", "dev", "carts")
+			case utils.RemediationURI:
+				_, err = k.GetRemediations("sockshop", "dev", "carts")
+			}
+			problems, ok := err.(utils.ValidationErrors)
+			if !ok {
+				t.Fatalf("got error %v, want validation errors", err)
+			}
+			if !reflect.DeepEqual(problems, tt.wantErrors) {
+				t.Errorf("got validation errors\n%v\nwant\n%v", problems, tt.wantErrors)
+			}
+		})
+	}
+}
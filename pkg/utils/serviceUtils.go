@@ -1,13 +1,10 @@
 package utils
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strings"
 
 	"github.com/keptn/go-utils/pkg/models"
 )
@@ -19,35 +16,22 @@ type ServiceHandler struct {
 	AuthHeader string
 	HTTPClient *http.Client
 	Scheme     string
+	// RetryPolicy configures the retries of failed requests. Requests are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+	// Instrumentation observes all requests, e.g. for recording metrics. It is optional.
+	Instrumentation Instrumentation
+	// Authenticator adds the credentials to all requests. If it is nil, AuthToken is set in AuthHeader.
+	Authenticator Authenticator
 }
 
 // NewServiceHandler returns a new ServiceHandler
 func NewServiceHandler(baseURL string) *ServiceHandler {
-	baseURL = strings.TrimPrefix(baseURL, "http://")
-	baseURL = strings.TrimPrefix(baseURL, "https://")
-	return &ServiceHandler{
-		BaseURL:    baseURL,
-		AuthHeader: "",
-		AuthToken:  "",
-		HTTPClient: &http.Client{},
-		Scheme:     "http",
-	}
+	return NewAuthenticatedServiceHandler(baseURL, "", "", nil, "http")
 }
 
 // NewAuthenticatedServiceHandler returns a new ServiceHandler that authenticates at the endpoint via the provided token
 func NewAuthenticatedServiceHandler(baseURL string, authToken string, authHeader string, httpClient *http.Client, scheme string) *ServiceHandler {
-	if httpClient == nil {
-		httpClient = &http.Client{}
-	}
-	baseURL = strings.TrimPrefix(baseURL, "http://")
-	baseURL = strings.TrimPrefix(baseURL, "https://")
-	return &ServiceHandler{
-		BaseURL:    baseURL,
-		AuthHeader: authHeader,
-		AuthToken:  authToken,
-		HTTPClient: httpClient,
-		Scheme:     scheme,
-	}
+	return newClientFromHandlerArgs(baseURL, authToken, authHeader, httpClient, scheme).Services()
 }
 
 func (s *ServiceHandler) getBaseURL() string {
@@ -62,74 +46,113 @@ func (s *ServiceHandler) getAuthHeader() string {
 	return s.AuthHeader
 }
 
-func (s *ServiceHandler) getHTTPClient() *http.Client {
-	return s.HTTPClient
+func (s *ServiceHandler) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return s.HTTPClient.Do(req.WithContext(ctx))
+}
+
+func (s *ServiceHandler) getRetryPolicy() *RetryPolicy {
+	return s.RetryPolicy
+}
+
+func (s *ServiceHandler) getInstrumentation() Instrumentation {
+	return s.Instrumentation
+}
+
+func (s *ServiceHandler) getAuthenticator() Authenticator {
+	return s.Authenticator
+}
+
+// ConfigureTLS applies the TLS options to the HTTP client of the ServiceHandler.
+// Other HTTP clients of the process are not affected.
+func (s *ServiceHandler) ConfigureTLS(opts TLSOptions) error {
+	httpClient, err := newTLSHTTPClient(s.HTTPClient, opts)
+	if err != nil {
+		return err
+	}
+	s.HTTPClient = httpClient
+	return nil
 }
 
 // CreateService creates a new service
-func (s *ServiceHandler) CreateService(project string, stage string, serviceName string) (*models.Error, error) {
+func (s *ServiceHandler) CreateService(project string, stage string, serviceName string) error {
+	return s.CreateServiceWithContext(context.Background(), project, stage, serviceName)
+}
+
+// CreateServiceWithContext creates a new service
+func (s *ServiceHandler) CreateServiceWithContext(ctx context.Context, project string, stage string, serviceName string) error {
+	ctx = withOperation(ctx, "CreateService")
 
 	service := models.Service{ServiceName: serviceName}
 	body, err := json.Marshal(service)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return post(s.Scheme+"://"+s.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service", body, s)
+	return post(ctx, s.Scheme+"://"+s.BaseURL+"/v1/project/"+project+"/stage/"+stage+"/service", body, s)
 }
 
 // GetAllServices returns a list of all services.
 func (s *ServiceHandler) GetAllServices(project string, stage string) ([]*models.Service, error) {
+	return s.GetAllServicesWithContext(context.Background(), project, stage)
+}
 
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+// GetAllServicesWithContext returns a list of all services.
+func (s *ServiceHandler) GetAllServicesWithContext(ctx context.Context, project string, stage string) ([]*models.Service, error) {
+	ctx = withOperation(ctx, "GetAllServices")
 	services := []*models.Service{}
+	it := s.IterateServices(ctx, project, stage, 0)
+	for it.Next() {
+		services = append(services, it.Service())
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return services, nil
+}
 
-	nextPageKey := ""
-
-	for {
-		url, err := url.Parse(s.Scheme + "://" + s.getBaseURL() + "/v1/project/" + project + "/stage/" + stage + "/service")
-		if err != nil {
-			return nil, err
-		}
-		q := url.Query()
-		if nextPageKey != "" {
-			q.Set("nextPageKey", nextPageKey)
-		}
-		req, err := http.NewRequest("GET", url.String(), nil)
-		req.Header.Set("Content-Type", "application/json")
-		addAuthHeader(req, s)
-
-		resp, err := s.HTTPClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		if resp.StatusCode == 200 {
-			var received models.Services
-			err = json.Unmarshal(body, &received)
-			if err != nil {
-				return nil, err
-			}
-			services = append(services, received.Services...)
-
-			if received.NextPageKey == "" || received.NextPageKey == "0" {
-				break
-			}
-			nextPageKey = received.NextPageKey
-		} else {
-			var respErr models.Error
-			err = json.Unmarshal(body, &respErr)
-			if err != nil {
-				return nil, err
-			}
-			return nil, errors.New("Response Error Code: " + string(respErr.Code) + " Message: " + *respErr.Message)
-		}
+// IterateServices returns an iterator over all services of a stage, which fetches pages of the provided size on demand.
+// If pageSize is 0, the page size of the configuration service is used.
+func (s *ServiceHandler) IterateServices(ctx context.Context, project string, stage string, pageSize int) ServiceIterator {
+	ctx = withOperation(ctx, "IterateServices")
+	return &serviceIterator{pager: newPager(ctx, s, s.Scheme+"://"+s.getBaseURL()+"/v1/project/"+project+"/stage/"+stage+"/service", pageSize)}
+}
+
+// GetService returns a service
+func (s *ServiceHandler) GetService(project string, stage string, serviceName string) (*models.Service, error) {
+	return s.GetServiceWithContext(context.Background(), project, stage, serviceName)
+}
+
+// GetServiceWithContext returns a service
+func (s *ServiceHandler) GetServiceWithContext(ctx context.Context, project string, stage string, serviceName string) (*models.Service, error) {
+	ctx = withOperation(ctx, "GetService")
+	var service models.Service
+	if err := get(ctx, s.Scheme+"://"+s.getBaseURL()+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(serviceName), s, &service); err != nil {
+		return nil, err
 	}
+	return &service, nil
+}
 
-	return services, nil
+// UpdateService updates the service with the provided name
+func (s *ServiceHandler) UpdateService(project string, stage string, serviceName string, service models.Service) error {
+	return s.UpdateServiceWithContext(context.Background(), project, stage, serviceName, service)
+}
+
+// UpdateServiceWithContext updates the service with the provided name
+func (s *ServiceHandler) UpdateServiceWithContext(ctx context.Context, project string, stage string, serviceName string, service models.Service) error {
+	ctx = withOperation(ctx, "UpdateService")
+	body, err := json.Marshal(service)
+	if err != nil {
+		return err
+	}
+	return put(ctx, s.Scheme+"://"+s.getBaseURL()+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(serviceName), body, s)
+}
+
+// DeleteService deletes a service
+func (s *ServiceHandler) DeleteService(project string, stage string, serviceName string) error {
+	return s.DeleteServiceWithContext(context.Background(), project, stage, serviceName)
+}
+
+// DeleteServiceWithContext deletes a service
+func (s *ServiceHandler) DeleteServiceWithContext(ctx context.Context, project string, stage string, serviceName string) error {
+	ctx = withOperation(ctx, "DeleteService")
+	return delete(ctx, s.Scheme+"://"+s.getBaseURL()+"/v1/project/"+project+"/stage/"+stage+"/service/"+url.QueryEscape(serviceName), s)
 }
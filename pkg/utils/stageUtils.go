@@ -1,13 +1,9 @@
 package utils
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
 	"net/http"
-	"net/url"
-	"strings"
 
 	"github.com/keptn/go-utils/pkg/models"
 )
@@ -19,35 +15,22 @@ type StageHandler struct {
 	AuthHeader string
 	HTTPClient *http.Client
 	Scheme     string
+	// RetryPolicy configures the retries of failed requests. Requests are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+	// Instrumentation observes all requests, e.g. for recording metrics. It is optional.
+	Instrumentation Instrumentation
+	// Authenticator adds the credentials to all requests. If it is nil, AuthToken is set in AuthHeader.
+	Authenticator Authenticator
 }
 
 // NewStageHandler returns a new StageHandler
 func NewStageHandler(baseURL string) *StageHandler {
-	baseURL = strings.TrimPrefix(baseURL, "http://")
-	baseURL = strings.TrimPrefix(baseURL, "https://")
-	return &StageHandler{
-		BaseURL:    baseURL,
-		AuthHeader: "",
-		AuthToken:  "",
-		HTTPClient: &http.Client{},
-		Scheme:     "http",
-	}
+	return NewAuthenticatedStageHandler(baseURL, "", "", nil, "http")
 }
 
 // NewAuthenticatedStageHandler returns a new StageHandler that authenticates at the endpoint via the provided token
 func NewAuthenticatedStageHandler(baseURL string, authToken string, authHeader string, httpClient *http.Client, scheme string) *StageHandler {
-	if httpClient == nil {
-		httpClient = &http.Client{}
-	}
-	baseURL = strings.TrimPrefix(baseURL, "http://")
-	baseURL = strings.TrimPrefix(baseURL, "https://")
-	return &StageHandler{
-		BaseURL:    baseURL,
-		AuthHeader: authHeader,
-		AuthToken:  authToken,
-		HTTPClient: httpClient,
-		Scheme:     scheme,
-	}
+	return newClientFromHandlerArgs(baseURL, authToken, authHeader, httpClient, scheme).Stages()
 }
 
 func (s *StageHandler) getBaseURL() string {
@@ -62,69 +45,113 @@ func (s *StageHandler) getAuthHeader() string {
 	return s.AuthHeader
 }
 
-func (s *StageHandler) getHTTPClient() *http.Client {
-	return s.HTTPClient
+func (s *StageHandler) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return s.HTTPClient.Do(req.WithContext(ctx))
+}
+
+func (s *StageHandler) getRetryPolicy() *RetryPolicy {
+	return s.RetryPolicy
+}
+
+func (s *StageHandler) getInstrumentation() Instrumentation {
+	return s.Instrumentation
+}
+
+func (s *StageHandler) getAuthenticator() Authenticator {
+	return s.Authenticator
+}
+
+// ConfigureTLS applies the TLS options to the HTTP client of the StageHandler.
+// Other HTTP clients of the process are not affected.
+func (s *StageHandler) ConfigureTLS(opts TLSOptions) error {
+	httpClient, err := newTLSHTTPClient(s.HTTPClient, opts)
+	if err != nil {
+		return err
+	}
+	s.HTTPClient = httpClient
+	return nil
 }
 
 // CreateStage creates a new stage with the provided name
-func (s *StageHandler) CreateStage(project string, stageName string) (*models.Error, error) {
+func (s *StageHandler) CreateStage(project string, stageName string) error {
+	return s.CreateStageWithContext(context.Background(), project, stageName)
+}
+
+// CreateStageWithContext creates a new stage with the provided name
+func (s *StageHandler) CreateStageWithContext(ctx context.Context, project string, stageName string) error {
+	ctx = withOperation(ctx, "CreateStage")
 
 	stage := models.Stage{StageName: stageName}
 	body, err := json.Marshal(stage)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return post(s.Scheme+"://"+s.BaseURL+"/v1/project/"+project+"/stage", body, s)
+	return post(ctx, s.Scheme+"://"+s.BaseURL+"/v1/project/"+project+"/stage", body, s)
 }
 
 // GetAllStages returns a list of all stages.
 func (s *StageHandler) GetAllStages(project string) ([]*models.Stage, error) {
+	return s.GetAllStagesWithContext(context.Background(), project)
+}
 
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+// GetAllStagesWithContext returns a list of all stages.
+func (s *StageHandler) GetAllStagesWithContext(ctx context.Context, project string) ([]*models.Stage, error) {
+	ctx = withOperation(ctx, "GetAllStages")
 	stages := []*models.Stage{}
-
-	nextPageKey := ""
-	for {
-		url, err := url.Parse(s.Scheme + "://" + s.getBaseURL() + "/v1/project/" + project + "/stage")
-		if err != nil {
-			return nil, err
-		}
-		q := url.Query()
-		if nextPageKey != "" {
-			q.Set("nextPageKey", nextPageKey)
-		}
-		req, err := http.NewRequest("GET", url.String(), nil)
-		req.Header.Set("Content-Type", "application/json")
-		addAuthHeader(req, s)
-
-		resp, err := s.HTTPClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-
-		body, _ := ioutil.ReadAll(resp.Body)
-
-		if resp.StatusCode == 200 {
-			var received models.Stages
-			err = json.Unmarshal(body, &received)
-			if err != nil {
-				return nil, err
-			}
-			stages = append(stages, received.Stages...)
-
-			if received.NextPageKey == "" || received.NextPageKey == "0" {
-				break
-			}
-			nextPageKey = received.NextPageKey
-		} else {
-			var respErr models.Error
-			err = json.Unmarshal(body, &respErr)
-			if err != nil {
-				return nil, err
-			}
-			return nil, errors.New("Response Error Code: " + string(respErr.Code) + " Message: " + *respErr.Message)
-		}
+	it := s.IterateStages(ctx, project, 0)
+	for it.Next() {
+		stages = append(stages, it.Stage())
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
 	}
 	return stages, nil
 }
+
+// IterateStages returns an iterator over all stages of a project, which fetches pages of the provided size on demand.
+// If pageSize is 0, the page size of the configuration service is used.
+func (s *StageHandler) IterateStages(ctx context.Context, project string, pageSize int) StageIterator {
+	ctx = withOperation(ctx, "IterateStages")
+	return &stageIterator{pager: newPager(ctx, s, s.Scheme+"://"+s.getBaseURL()+"/v1/project/"+project+"/stage", pageSize)}
+}
+
+// GetStage returns a stage
+func (s *StageHandler) GetStage(project string, stageName string) (*models.Stage, error) {
+	return s.GetStageWithContext(context.Background(), project, stageName)
+}
+
+// GetStageWithContext returns a stage
+func (s *StageHandler) GetStageWithContext(ctx context.Context, project string, stageName string) (*models.Stage, error) {
+	ctx = withOperation(ctx, "GetStage")
+	var stage models.Stage
+	if err := get(ctx, s.Scheme+"://"+s.getBaseURL()+"/v1/project/"+project+"/stage/"+stageName, s, &stage); err != nil {
+		return nil, err
+	}
+	return &stage, nil
+}
+
+// UpdateStage updates the stage with the provided name
+func (s *StageHandler) UpdateStage(project string, stageName string, stage models.Stage) error {
+	return s.UpdateStageWithContext(context.Background(), project, stageName, stage)
+}
+
+// UpdateStageWithContext updates the stage with the provided name
+func (s *StageHandler) UpdateStageWithContext(ctx context.Context, project string, stageName string, stage models.Stage) error {
+	ctx = withOperation(ctx, "UpdateStage")
+	body, err := json.Marshal(stage)
+	if err != nil {
+		return err
+	}
+	return put(ctx, s.Scheme+"://"+s.getBaseURL()+"/v1/project/"+project+"/stage/"+stageName, body, s)
+}
+
+// DeleteStage deletes a stage
+func (s *StageHandler) DeleteStage(project string, stageName string) error {
+	return s.DeleteStageWithContext(context.Background(), project, stageName)
+}
+
+// DeleteStageWithContext deletes a stage
+func (s *StageHandler) DeleteStageWithContext(ctx context.Context, project string, stageName string) error {
+	ctx = withOperation(ctx, "DeleteStage")
+	return delete(ctx, s.Scheme+"://"+s.getBaseURL()+"/v1/project/"+project+"/stage/"+stageName, s)
+}
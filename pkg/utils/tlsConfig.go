@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// TLSOptions describes the TLS configuration used for connecting to the configuration service
+type TLSOptions struct {
+	// CACertFile is the path to a PEM encoded CA bundle used for verifying the server certificate
+	CACertFile string
+	// CACertPEM contains a PEM encoded CA bundle used for verifying the server certificate
+	CACertPEM []byte
+	// ClientCertFile is the path to a PEM encoded client certificate used for mutual TLS
+	ClientCertFile string
+	// ClientKeyFile is the path to the PEM encoded private key of the client certificate
+	ClientKeyFile string
+	// ClientCertPEM contains a PEM encoded client certificate used for mutual TLS
+	ClientCertPEM []byte
+	// ClientKeyPEM contains the PEM encoded private key of the client certificate
+	ClientKeyPEM []byte
+	// ServerName overrides the server name used for verifying the server certificate
+	ServerName string
+	// InsecureSkipVerify disables the verification of the server certificate.
+	// It only affects the handler the options are applied to.
+	InsecureSkipVerify bool
+}
+
+// BuildTLSConfig returns the tls.Config described by the options
+func (o TLSOptions) BuildTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	caCerts := o.CACertPEM
+	if o.CACertFile != "" {
+		data, err := ioutil.ReadFile(o.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error when reading CA bundle: %s", err.Error())
+		}
+		caCerts = append(append([]byte{}, caCerts...), data...)
+	}
+	if len(caCerts) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCerts) {
+			return nil, errors.New("Error when reading CA bundle: no valid certificate found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	var cert tls.Certificate
+	var err error
+	switch {
+	case o.ClientCertFile != "" || o.ClientKeyFile != "":
+		cert, err = tls.LoadX509KeyPair(o.ClientCertFile, o.ClientKeyFile)
+	case len(o.ClientCertPEM) > 0 || len(o.ClientKeyPEM) > 0:
+		cert, err = tls.X509KeyPair(o.ClientCertPEM, o.ClientKeyPEM)
+	default:
+		return tlsConfig, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error when reading client certificate: %s", err.Error())
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return tlsConfig, nil
+}
+
+// newTLSHTTPClient returns a copy of the provided client whose transport uses the TLS options.
+// The transport of the provided client and http.DefaultTransport are left untouched.
+func newTLSHTTPClient(httpClient *http.Client, opts TLSOptions) (*http.Client, error) {
+	tlsConfig, err := opts.BuildTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	newClient := &http.Client{}
+	if httpClient != nil {
+		*newClient = *httpClient
+	}
+	newClient.Transport, err = newTLSTransport(newClient.Transport, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newClient, nil
+}
+
+// newTLSTransport returns a copy of the transport using the TLS configuration. The transport of a Client
+// created with a user agent, logger or timeout is unwrapped for applying the configuration.
+func newTLSTransport(rt http.RoundTripper, tlsConfig *tls.Config) (http.RoundTripper, error) {
+	switch t := rt.(type) {
+	case nil:
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		return transport, nil
+	case *http.Transport:
+		transport := t.Clone()
+		transport.TLSClientConfig = tlsConfig
+		return transport, nil
+	case *clientTransport:
+		next, err := newTLSTransport(t.next, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		wrapper := *t
+		wrapper.next = next
+		return &wrapper, nil
+	default:
+		return nil, errors.New("TLS options can only be applied to clients using an *http.Transport")
+	}
+}
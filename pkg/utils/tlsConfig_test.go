@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCert is a certificate with its PEM encoding
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func (c *testCert) tlsCertificate(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// newTestCert creates a certificate signed by parent or, if parent is nil, a self-signed CA
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeTempFile(t *testing.T, dir string, name string, content []byte) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewTLSHTTPClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keptn-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca := newTestCert(t, "keptn-ca", nil)
+	serverCert := newTestCert(t, "configuration-service", ca)
+	clientCert := newTestCert(t, "keptn-client", ca)
+	caFile := writeTempFile(t, dir, "ca.pem", ca.certPEM)
+	clientCertFile := writeTempFile(t, dir, "client.pem", clientCert.certPEM)
+	clientKeyFile := writeTempFile(t, dir, "client-key.pem", clientCert.keyPEM)
+
+	newServer := func(requireClientCert bool) *httptest.Server {
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		srv.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert.tlsCertificate(t)}}
+		if requireClientCert {
+			pool := x509.NewCertPool()
+			pool.AddCert(ca.cert)
+			srv.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+			srv.TLS.ClientCAs = pool
+		}
+		srv.StartTLS()
+		return srv
+	}
+
+	tests := []struct {
+		name              string
+		opts              TLSOptions
+		requireClientCert bool
+		wantErr           bool
+	}{
+		{name: "unknown CA", opts: TLSOptions{}, wantErr: true},
+		{name: "CA file", opts: TLSOptions{CACertFile: caFile}},
+		{name: "CA PEM", opts: TLSOptions{CACertPEM: ca.certPEM}},
+		{name: "skip verification", opts: TLSOptions{InsecureSkipVerify: true}},
+		{name: "wrong server name", opts: TLSOptions{CACertFile: caFile, ServerName: "other"}, wantErr: true},
+		{name: "missing client certificate", opts: TLSOptions{CACertFile: caFile}, requireClientCert: true, wantErr: true},
+		{name: "client certificate files", opts: TLSOptions{CACertFile: caFile, ClientCertFile: clientCertFile, ClientKeyFile: clientKeyFile}, requireClientCert: true},
+		{name: "client certificate PEM", opts: TLSOptions{CACertFile: caFile, ClientCertPEM: clientCert.certPEM, ClientKeyPEM: clientCert.keyPEM}, requireClientCert: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(tt.requireClientCert)
+			defer srv.Close()
+
+			client, err := newTLSHTTPClient(&http.Client{Timeout: 5 * time.Second}, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client.Timeout != 5*time.Second {
+				t.Errorf("got timeout %s, want the timeout of the provided client", client.Timeout)
+			}
+			resp, err := client.Get(srv.URL)
+			if tt.wantErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Error("got no error, want a TLS error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestNewTLSHTTPClientErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keptn-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	invalidFile := writeTempFile(t, dir, "invalid.pem", []byte("no certificate"))
+
+	tests := []struct {
+		name       string
+		httpClient *http.Client
+		opts       TLSOptions
+	}{
+		{name: "missing CA file", opts: TLSOptions{CACertFile: filepath.Join(dir, "missing.pem")}},
+		{name: "invalid CA bundle", opts: TLSOptions{CACertFile: invalidFile}},
+		{name: "client certificate without key", opts: TLSOptions{ClientCertFile: invalidFile}},
+		{name: "invalid client certificate", opts: TLSOptions{ClientCertPEM: []byte("x"), ClientKeyPEM: []byte("y")}},
+		{name: "transport which is no *http.Transport", httpClient: &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newTLSHTTPClient(tt.httpClient, tt.opts); err == nil {
+				t.Error("got no error, want an error")
+			}
+		})
+	}
+}
+
+func TestNewTLSHTTPClientKeepsTransport(t *testing.T) {
+	transport := &http.Transport{MaxIdleConns: 7}
+	client, err := newTLSHTTPClient(&http.Client{Transport: transport}, TLSOptions{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// cloning a transport sets up HTTP/2, which may add a TLS configuration, so only the options are checked
+	if transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("the transport of the provided client has been modified")
+	}
+	if defaultTLS := http.DefaultTransport.(*http.Transport).TLSClientConfig; defaultTLS != nil && defaultTLS.InsecureSkipVerify {
+		t.Error("the default transport has been modified")
+	}
+	newTransport := client.Transport.(*http.Transport)
+	if newTransport.MaxIdleConns != 7 || !newTransport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("got transport %+v, want a copy of the provided transport with the TLS options", newTransport)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+)
+
+// Span attribute keys set by TracingInstrumentation
+const (
+	SpanAttributeHTTPMethod     = "http.method"
+	SpanAttributeHTTPURL        = "http.url"
+	SpanAttributeHTTPStatusCode = "http.status_code"
+	SpanAttributeKeptnContext   = "keptn.context"
+	SpanAttributeAttempts       = "keptn.configservice.attempts"
+)
+
+// Span is a unit of work of a trace. Its methods correspond to those of an OpenTelemetry span,
+// so that spans of OpenTelemetry or OpenCensus can be adapted with a few lines.
+type Span interface {
+	// SetAttribute sets an attribute of the span
+	SetAttribute(key string, value interface{})
+	// RecordError marks the span as failed
+	RecordError(err error)
+	// End completes the span
+	End()
+}
+
+// Tracer starts spans
+type Tracer interface {
+	// Start starts a span as child of the span contained in ctx, if any, and returns a context containing the new span
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// TracingInstrumentation creates a span for each request sent to the configuration service.
+// The span is named after the handler operation and carries the keptn context of the request.
+type TracingInstrumentation struct {
+	Tracer Tracer
+}
+
+// NewTracingInstrumentation returns a new TracingInstrumentation starting spans with the provided tracer
+func NewTracingInstrumentation(tracer Tracer) *TracingInstrumentation {
+	return &TracingInstrumentation{
+		Tracer: tracer,
+	}
+}
+
+// StartRequest starts the span of a request
+func (t *TracingInstrumentation) StartRequest(ctx context.Context, info RequestInfo) (context.Context, func(RequestResult)) {
+	spanName := info.Operation
+	if spanName == "" {
+		spanName = info.Method
+	}
+	ctx, span := t.Tracer.Start(ctx, "configservice "+spanName)
+	span.SetAttribute(SpanAttributeHTTPMethod, info.Method)
+	span.SetAttribute(SpanAttributeHTTPURL, info.URI)
+	if info.KeptnContext != "" {
+		span.SetAttribute(SpanAttributeKeptnContext, info.KeptnContext)
+	}
+
+	return ctx, func(result RequestResult) {
+		if result.StatusCode != 0 {
+			span.SetAttribute(SpanAttributeHTTPStatusCode, result.StatusCode)
+		}
+		span.SetAttribute(SpanAttributeAttempts, result.Attempts)
+		if result.Err != nil {
+			span.RecordError(result.Err)
+		}
+		span.End()
+	}
+}
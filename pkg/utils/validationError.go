@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError describes a problem in a resource of the configuration service
+type ValidationError struct {
+	// Resource is the path of the resource, e.g. sockshop/dev/carts/service-objectives.yaml
+	Resource string
+	// Line is the line of the resource the problem has been found in. It is 0 if the problem does not refer to a line.
+	Line int
+	// Message describes the problem
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Resource, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Resource, e.Message)
+}
+
+// ValidationErrors is returned if one or more resources are invalid
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := []string{}
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return "Invalid configuration: " + strings.Join(messages, "; ")
+}